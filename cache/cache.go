@@ -3,10 +3,21 @@ package cache
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/constants"
+	"github.com/scache-io/scache/errors"
 	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/internal"
 	"github.com/scache-io/scache/storage"
 	"github.com/scache-io/scache/types"
 	"github.com/scache-io/scache/utils"
@@ -17,32 +28,153 @@ func NewEngine(engineConfig *config.EngineConfig) interfaces.StorageEngine {
 	return storage.NewStorageEngine(engineConfig)
 }
 
+// keyLockStripes 按键加锁的分段数量
+const keyLockStripes = 256
+
 // LocalCache Local cache wrapper
 type LocalCache struct {
-	engine interfaces.StorageEngine
+	engine      interfaces.StorageEngine
+	keyLocks    [keyLockStripes]sync.Mutex // 按键分段锁，用于Update等读-改-写操作
+	useNumber   bool                       // JSON解码时使用json.Number
+	transformer *config.ValueTransformer   // 字符串/结构体值的转换，nil表示不转换
+	loads       internal.Group             // 合并GetOrStore对同一键的并发加载
+	loadTimeout time.Duration              // GetOrStore加载超时，0表示不限制
+	persistPath string                     // 关闭时持久化的文件路径，空表示不持久化
+	restoreErr  error                      // 从persistPath恢复失败的原因，非nil时关闭时不覆盖该文件
+	clock       func() time.Time           // 计算剩余生存时间使用的时钟，nil表示time.Now
+	normalize   func(string) string        // 与引擎一致的键规范化函数，nil表示不处理
 }
 
 // NewLocalCache Create local cache instance
 func NewLocalCache(engineConfig *config.EngineConfig) *LocalCache {
-	return &LocalCache{
-		engine: NewEngine(engineConfig),
+	c := &LocalCache{engine: NewEngine(engineConfig)}
+	if engineConfig != nil {
+		c.useNumber = engineConfig.UseJSONNumber
+		c.transformer = engineConfig.ValueTransformer
+		c.persistPath = engineConfig.PersistPath
+		c.loadTimeout = engineConfig.LoaderTimeout
+		c.clock = engineConfig.Clock
+		c.normalize = engineConfig.KeyNormalizer
+	}
+	if err := c.restore(); err != nil {
+		c.restoreErr = err
+		if engineConfig.OnRestoreError != nil {
+			engineConfig.OnRestoreError(c.persistPath, err)
+		}
 	}
+	return c
+}
+
+// restore 从持久化文件恢复数据，文件不存在时跳过；文件损坏时保留已恢复的记录并返回错误
+func (c *LocalCache) restore() error {
+	if c.persistPath == "" {
+		return nil
+	}
+	f, err := os.Open(c.persistPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := c.engine.Import(f); err != nil {
+		return fmt.Errorf("restore %s: %w", c.persistPath, err)
+	}
+	return nil
+}
+
+// persist 将未过期数据导出到持久化文件，先写临时文件再重命名，避免中途失败留下不完整的文件
+func (c *LocalCache) persist() error {
+	f, err := os.CreateTemp(filepath.Dir(c.persistPath), filepath.Base(c.persistPath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := c.engine.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.persistPath)
+}
+
+// encode 按配置转换写入的字符串值
+func (c *LocalCache) encode(value string) (string, error) {
+	if c.transformer == nil {
+		return value, nil
+	}
+
+	encoded, err := c.transformer.Encode([]byte(value))
+	if err != nil {
+		return "", fmt.Errorf("encode value: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// decode 按配置还原读取的字符串值
+func (c *LocalCache) decode(value string) (string, error) {
+	if c.transformer == nil {
+		return value, nil
+	}
+
+	decoded, err := c.transformer.Decode([]byte(value))
+	if err != nil {
+		return "", fmt.Errorf("decode value: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// extractString 提取并还原字符串值，类型不匹配时返回ErrTypeMismatch
+func (c *LocalCache) extractString(obj interfaces.DataObject) (string, error) {
+	value, ok := utils.ExtractStringValue(obj)
+	if !ok {
+		return "", errors.ErrTypeMismatch
+	}
+	return c.decode(value)
 }
 
 // SetString Set string value
 func (c *LocalCache) SetString(key, value string, ttl ...time.Duration) error {
+	value, err := c.encode(value)
+	if err != nil {
+		return err
+	}
+
 	obj := types.NewStringObject(value, utils.ParseTTL(ttl))
 	return c.engine.Set(key, obj)
 }
 
-// GetString Get string value
+// GetString Get string value，值无法还原时按未命中处理
+// 空字符串值返回("", true)，与键不存在的("", false)通过第二个返回值区分
 func (c *LocalCache) GetString(key string) (string, bool) {
 	obj, exists := c.engine.Get(key)
 	if !exists {
 		return "", false
 	}
 
-	return utils.ExtractStringValue(obj)
+	value, err := c.extractString(obj)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// GetStringE 获取字符串值，与GetString不同，键不是字符串或值无法还原时返回错误（类型不匹配为ErrTypeMismatch）
+func (c *LocalCache) GetStringE(key string) (string, bool, error) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return "", false, c.closedErr()
+	}
+
+	value, err := c.extractString(obj)
+	if err != nil {
+		return "", true, err
+	}
+	return value, true, nil
 }
 
 // SetList Set list value
@@ -61,6 +193,59 @@ func (c *LocalCache) GetList(key string) ([]interface{}, bool) {
 	return utils.ExtractListValue(obj)
 }
 
+// LPush 在列表头部写入values，返回写入后的长度，键不存在时创建
+func (c *LocalCache) LPush(key string, values ...interface{}) (int, error) {
+	return c.engine.LPush(key, values...)
+}
+
+// RPush 在列表尾部写入values，返回写入后的长度，键不存在时创建
+func (c *LocalCache) RPush(key string, values ...interface{}) (int, error) {
+	return c.engine.RPush(key, values...)
+}
+
+// LPop 移除并返回列表头部元素，列表为空时删除键
+func (c *LocalCache) LPop(key string) (interface{}, bool) {
+	return c.engine.LPop(key)
+}
+
+// RPop 移除并返回列表尾部元素，列表为空时删除键
+func (c *LocalCache) RPop(key string) (interface{}, bool) {
+	return c.engine.RPop(key)
+}
+
+// LMove 在一次加锁内从src的一端取出元素并写入dst的一端，返回移动的元素，src为空时返回nil
+func (c *LocalCache) LMove(src, dst string, srcEnd, dstEnd interfaces.ListEnd) (interface{}, error) {
+	return c.engine.LMove(src, dst, srcEnd, dstEnd)
+}
+
+// LLen 获取列表长度，键不存在或不是列表时返回0
+func (c *LocalCache) LLen(key string) int {
+	list, ok := c.list(key)
+	if !ok {
+		return 0
+	}
+	return list.Len()
+}
+
+// LRange 返回闭区间[start, end]内的元素，支持负数索引（-1表示最后一个元素），越界时截断，无元素时返回空切片
+func (c *LocalCache) LRange(key string, start, end int) []interface{} {
+	list, ok := c.list(key)
+	if !ok {
+		return []interface{}{}
+	}
+	return list.Range(start, end)
+}
+
+// list 获取键对应的列表对象
+func (c *LocalCache) list(key string) (interfaces.ListObject, bool) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return nil, false
+	}
+	list, ok := obj.(interfaces.ListObject)
+	return list, ok
+}
+
 // SetHash Set hash value
 func (c *LocalCache) SetHash(key string, fields map[string]interface{}, ttl ...time.Duration) error {
 	obj := types.NewHashObject(fields, utils.ParseTTL(ttl))
@@ -77,6 +262,200 @@ func (c *LocalCache) GetHash(key string) (map[string]interface{}, bool) {
 	return utils.ExtractHashValue(obj)
 }
 
+// LPushCap 在固定容量环形列表头部写入，写满时丢弃尾部最旧的元素，键不存在时按capacity创建
+func (c *LocalCache) LPushCap(key string, capacity int, value interface{}) error {
+	return c.engine.LPushCap(key, capacity, value)
+}
+
+// RPushCap 在固定容量环形列表尾部写入，写满时丢弃头部最旧的元素，键不存在时按capacity创建
+func (c *LocalCache) RPushCap(key string, capacity int, value interface{}) error {
+	return c.engine.RPushCap(key, capacity, value)
+}
+
+// Reserve 预留键的容量槽位，在构建值期间防止其被淘汰，预留期间读取按未命中处理
+// commit按值类型写入（string、[]interface{}、map[string]interface{}，其他类型按JSON存储）并结束预留，
+// cancel释放预留；预留失败时commit返回该错误
+func (c *LocalCache) Reserve(key string) (commit func(value interface{}, ttl time.Duration) error, cancel func()) {
+	if err := c.engine.Reserve(key); err != nil {
+		return func(interface{}, time.Duration) error { return err }, func() {}
+	}
+
+	commit = func(value interface{}, ttl time.Duration) error {
+		obj, err := c.newObject(value, ttl)
+		if err != nil {
+			c.engine.CancelReservation(key)
+			return err
+		}
+		return c.engine.Set(key, obj)
+	}
+	cancel = func() {
+		c.engine.CancelReservation(key)
+	}
+	return commit, cancel
+}
+
+// newObject 按值类型创建数据对象，非字符串/列表/哈希的值按JSON序列化存储
+func (c *LocalCache) newObject(value interface{}, ttl time.Duration) (interfaces.DataObject, error) {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []interface{}:
+		return types.NewListObject(v, ttl), nil
+	case map[string]interface{}:
+		return types.NewHashObject(v, ttl), nil
+	default:
+		jsonBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		str = string(jsonBytes)
+	}
+
+	str, err := c.encode(str)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewStringObject(str, ttl), nil
+}
+
+// HSet 设置Hash字段，键不存在时创建
+func (c *LocalCache) HSet(key, field string, value interface{}) error {
+	return c.engine.HSet(key, field, value)
+}
+
+// HDel 删除Hash字段，最后一个字段被删除时删除整个键
+func (c *LocalCache) HDel(key, field string) bool {
+	return c.engine.HDel(key, field)
+}
+
+// HDelMany 删除多个Hash字段，返回实际删除的字段数
+func (c *LocalCache) HDelMany(key string, fields ...string) int {
+	return c.engine.HDelMany(key, fields...)
+}
+
+// HGetAll 获取Hash的所有字段，等同于GetHash
+func (c *LocalCache) HGetAll(key string) (map[string]interface{}, bool) {
+	return c.GetHash(key)
+}
+
+// HKeys 获取Hash的所有字段名（已排序）
+func (c *LocalCache) HKeys(key string) ([]string, bool) {
+	fields, ok := c.GetHash(key)
+	if !ok {
+		return nil, false
+	}
+	return sortedFieldNames(fields), true
+}
+
+// HVals 获取Hash的所有字段值，顺序与HKeys一致
+func (c *LocalCache) HVals(key string) ([]interface{}, bool) {
+	fields, ok := c.GetHash(key)
+	if !ok {
+		return nil, false
+	}
+	keys := sortedFieldNames(fields)
+	values := make([]interface{}, len(keys))
+	for i, field := range keys {
+		values[i] = fields[field]
+	}
+	return values, true
+}
+
+// HLen 获取Hash的字段数，键不存在或不是Hash时返回0
+func (c *LocalCache) HLen(key string) int {
+	hash, ok := c.hash(key)
+	if !ok {
+		return 0
+	}
+	return hash.Len()
+}
+
+// HExists 检查Hash中是否存在field字段
+func (c *LocalCache) HExists(key, field string) bool {
+	hash, ok := c.hash(key)
+	if !ok {
+		return false
+	}
+	_, exists := hash.Get(field)
+	return exists
+}
+
+// sortedFieldNames 返回排序后的字段名
+func sortedFieldNames(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for field := range fields {
+		keys = append(keys, field)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hash 获取键对应的Hash对象
+func (c *LocalCache) hash(key string) (interfaces.HashObject, bool) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return nil, false
+	}
+	hash, ok := obj.(interfaces.HashObject)
+	return hash, ok
+}
+
+// CreateIndex 为匹配keyPattern的Hash键的field字段建立二级索引
+func (c *LocalCache) CreateIndex(keyPattern, field string) error {
+	return c.engine.CreateIndex(keyPattern, field)
+}
+
+// LookupIndex 通过二级索引查找field字段等于value的键
+func (c *LocalCache) LookupIndex(field string, value interface{}) []string {
+	return c.engine.LookupIndex(field, value)
+}
+
+// SAdd 向Set添加成员，返回新增的成员数，键不存在时创建
+func (c *LocalCache) SAdd(key string, members ...interface{}) (int, error) {
+	return c.engine.SAdd(key, members...)
+}
+
+// SRem 从Set移除成员，返回移除的成员数，Set为空时删除键
+func (c *LocalCache) SRem(key string, members ...interface{}) (int, error) {
+	return c.engine.SRem(key, members...)
+}
+
+// SMembers 获取Set的所有成员
+func (c *LocalCache) SMembers(key string) ([]interface{}, bool) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return nil, false
+	}
+
+	return utils.ExtractSetValue(obj)
+}
+
+// SIsMember 检查member是否为Set的成员
+func (c *LocalCache) SIsMember(key string, member interface{}) bool {
+	set, ok := c.set(key)
+	return ok && set.Contains(member)
+}
+
+// SCard 获取Set的成员数，键不存在或不是Set时返回0
+func (c *LocalCache) SCard(key string) int {
+	set, ok := c.set(key)
+	if !ok {
+		return 0
+	}
+	return set.Len()
+}
+
+// set 获取键对应的Set对象
+func (c *LocalCache) set(key string) (interfaces.SetObject, bool) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return nil, false
+	}
+	set, ok := obj.(interfaces.SetObject)
+	return set, ok
+}
+
 // Store Store struct值（JSON序列化，支持指针和非指针Type）
 func (c *LocalCache) Store(key string, obj interface{}, ttl ...time.Duration) error {
 	jsonBytes, err := json.Marshal(obj)
@@ -84,7 +463,12 @@ func (c *LocalCache) Store(key string, obj interface{}, ttl ...time.Duration) er
 		return err
 	}
 
-	stringObj := types.NewStringObject(string(jsonBytes), utils.ParseTTL(ttl))
+	value, err := c.encode(string(jsonBytes))
+	if err != nil {
+		return err
+	}
+
+	stringObj := types.NewStringObject(value, utils.ParseTTL(ttl))
 	return c.engine.Set(key, stringObj)
 }
 
@@ -97,15 +481,269 @@ func (c *LocalCache) Load(key string, dest interface{}) error {
 
 	obj, exists := c.engine.Get(key)
 	if !exists {
-		return fmt.Errorf("key not found: %s", key)
+		if c.engine.Closed() {
+			return errors.ErrCacheClosed
+		}
+		return fmt.Errorf("%w: %s", errors.ErrKeyNotFound, key)
 	}
 
-	jsonData, ok := utils.ExtractStructValue(obj)
-	if !ok {
-		return fmt.Errorf("type mismatch")
+	jsonData, err := c.extractString(obj)
+	if err != nil {
+		return err
+	}
+
+	return c.unmarshal(jsonData, dest)
+}
+
+// unmarshal 按配置反序列化JSON数据
+func (c *LocalCache) unmarshal(data string, dest interface{}) error {
+	if !c.useNumber {
+		return json.Unmarshal([]byte(data), dest)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(dest)
+}
+
+// GetInt 获取整数值（字符串值按十进制解析），类型不匹配时返回错误
+func (c *LocalCache) GetInt(key string) (int, bool, error) {
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return 0, false, c.closedErr()
+	}
+
+	str, err := c.extractString(obj)
+	if err != nil {
+		return 0, true, err
+	}
+
+	value, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, true, fmt.Errorf("%w: %q is not an integer", errors.ErrTypeMismatch, str)
+	}
+	return value, true, nil
+}
+
+// Incr 原子地将整数字符串值加1并返回新值
+func (c *LocalCache) Incr(key string) (int64, error) {
+	return c.IncrBy(key, 1)
+}
+
+// Decr 原子地将整数字符串值减1并返回新值
+func (c *LocalCache) Decr(key string) (int64, error) {
+	return c.IncrBy(key, -1)
+}
+
+// IncrBy 原子地将整数字符串值加上delta并返回新值，键不存在时从0开始，保留原有的过期时间
+// 配置了ValueTransformer时在键级锁内解码、计算并写回，只与Update等键级锁操作互斥
+func (c *LocalCache) IncrBy(key string, delta int64) (int64, error) {
+	if c.transformer == nil {
+		return c.engine.IncrBy(key, delta)
+	}
+
+	mu := c.lockKey(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	value, ttl := "0", time.Duration(0)
+	if obj, exists := c.engine.Get(key); exists {
+		str, err := c.extractString(obj)
+		if err != nil {
+			return 0, err
+		}
+		value = str
+		if remaining, _ := utils.CalculateRemainingTTLAt(obj.ExpiresAt(), c.now()); remaining > 0 {
+			ttl = remaining
+		}
+	}
+
+	next, err := utils.IncrementInt(value, delta)
+	if err != nil {
+		return 0, err
+	}
+	return next, c.SetString(key, strconv.FormatInt(next, 10), ttl)
+}
+
+// GetStruct 按类型获取结构体值（JSON反序列化），未命中时返回found=false，类型不匹配时返回错误
+func GetStruct[T any](c *LocalCache, key string) (T, bool, error) {
+	var result T
+
+	obj, exists := c.engine.Get(key)
+	if !exists {
+		return result, false, c.closedErr()
+	}
+
+	jsonData, err := c.extractString(obj)
+	if err != nil {
+		return result, true, err
+	}
+
+	if err := c.unmarshal(jsonData, &result); err != nil {
+		return result, true, fmt.Errorf("%w: %v", errors.ErrTypeMismatch, err)
+	}
+	return result, true, nil
+}
+
+// GetOrStore 按类型读取键，未命中时调用loader加载并以Store的JSON格式写入
+// 同一键的并发未命中只调用一次loader，其余调用共享结果；loader返回错误时不写入
+// 配置了LoaderTimeout时，loader超时后所有等待者收到ErrLoaderTimeout，之后的调用重新加载
+func GetOrStore[V any](c *LocalCache, key string, ttl time.Duration, loader func() (V, error)) (V, error) {
+	if value, found, err := GetStruct[V](c, key); found || err != nil {
+		return value, err
+	}
+
+	// 按规范化后的键合并加载，指向同一条目的不同写法只加载一次
+	result, err, _ := c.loads.Do(c.normalizeKey(key), func() (interface{}, error) {
+		// 等待上一次加载期间值可能已写入
+		if value, found, err := GetStruct[V](c, key); found || err != nil {
+			return value, err
+		}
+
+		value, err := loadWithTimeout(c.loadTimeout, loader)
+		if err != nil {
+			return value, err
+		}
+		return value, c.Store(key, value, ttl)
+	})
+
+	// 同一键的并发调用使用了不同类型时，按自己的类型重新读取
+	value, ok := result.(V)
+	if !ok && err == nil {
+		value, _, err = GetStruct[V](c, key)
+	}
+	return value, err
+}
+
+// loadWithTimeout 调用loader，超过timeout未返回时返回ErrLoaderTimeout
+// 超时后loader在后台继续执行直到返回，其结果被丢弃，不会阻塞或泄漏等待结果的goroutine
+func loadWithTimeout[V any](timeout time.Duration, loader func() (V, error)) (V, error) {
+	if timeout <= 0 {
+		return loader()
+	}
+
+	type result struct {
+		value V
+		err   error
+	}
+	done := make(chan result, 1) // 带缓冲，超时后loader返回时不会阻塞
+	go func() {
+		value, err := loader()
+		done <- result{value: value, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-timer.C:
+		var zero V
+		return zero, fmt.Errorf("%w: no result after %v", errors.ErrLoaderTimeout, timeout)
+	}
+}
+
+// now 返回配置的Clock给出的当前时间，未配置时使用time.Now
+func (c *LocalCache) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// closedErr 缓存已关闭时返回ErrCacheClosed
+func (c *LocalCache) closedErr() error {
+	if c.engine.Closed() {
+		return errors.ErrCacheClosed
+	}
+	return nil
+}
+
+// Close 关闭缓存，之后的操作返回ErrCacheClosed或按未命中处理，可重复调用
+// 配置了PersistPath时先将数据持久化到文件，持久化失败时仍会关闭并返回该错误
+// 创建时未能从该文件恢复的，不覆盖文件以免丢失其中的数据
+func (c *LocalCache) Close() error {
+	if c.persistPath == "" || c.restoreErr != nil || c.engine.Closed() {
+		return c.engine.Close()
+	}
+	err := c.persist()
+	if closeErr := c.engine.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// normalizeKey 按与引擎一致的规则规范化键
+func (c *LocalCache) normalizeKey(key string) string {
+	if c.normalize == nil {
+		return key
+	}
+	return c.normalize(key)
+}
+
+// lockKey 获取键对应的分段锁，按规范化后的键选择分段，使指向同一条目的不同写法互斥
+func (c *LocalCache) lockKey(key string) *sync.Mutex {
+	key = c.normalizeKey(key)
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &c.keyLocks[h.Sum32()%keyLockStripes]
+}
+
+// Update 在键级锁内原子地读取、修改并存储struct值（JSON序列化）
+// 键不存在时fn接收nil，可用于创建值；fn返回错误时不写入
+// 注意：键级锁只在Update/UpdateStruct之间互斥，直接Store不受约束
+func (c *LocalCache) Update(key string, ttl time.Duration, fn func(cur interface{}) (interface{}, error)) error {
+	if err := c.closedErr(); err != nil {
+		return err
+	}
+
+	mu := c.lockKey(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	var cur interface{}
+	if obj, exists := c.engine.Get(key); exists {
+		jsonData, err := c.extractString(obj)
+		if err != nil {
+			return err
+		}
+		if err := c.unmarshal(jsonData, &cur); err != nil {
+			return fmt.Errorf("%w: %v", errors.ErrTypeMismatch, err)
+		}
+	}
+
+	next, err := fn(cur)
+	if err != nil {
+		return err
+	}
+	return c.Store(key, next, ttl)
+}
+
+// UpdateStruct Update的泛型版本，将当前值反序列化为T，键不存在时fn接收nil
+func UpdateStruct[T any](c *LocalCache, key string, ttl time.Duration, fn func(cur *T) (T, error)) error {
+	if err := c.closedErr(); err != nil {
+		return err
+	}
+
+	mu := c.lockKey(key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	cur, found, err := GetStruct[T](c, key)
+	if err != nil {
+		return err
+	}
+
+	var curPtr *T
+	if found {
+		curPtr = &cur
 	}
 
-	return json.Unmarshal([]byte(jsonData), dest)
+	next, err := fn(curPtr)
+	if err != nil {
+		return err
+	}
+	return c.Store(key, next, ttl)
 }
 
 // Delete Delete key
@@ -113,6 +751,127 @@ func (c *LocalCache) Delete(key string) bool {
 	return c.engine.Delete(key)
 }
 
+// DeleteMany 批量删除键，返回删除的键数和每个键是否被删除
+func (c *LocalCache) DeleteMany(keys ...string) (int, map[string]bool) {
+	return c.engine.DeleteMany(keys)
+}
+
+// Observe 监听键的变化，键被写入或修改时通道收到新值（字符串、[]interface{}或map[string]interface{}），
+// 键被删除或过期移除时通道关闭；投递不阻塞，慢消费者只会收到最新的值；调用返回的函数取消监听
+func (c *LocalCache) Observe(key string) (<-chan interface{}, func()) {
+	updates, cancel := c.engine.Watch(key)
+	if c.transformer == nil {
+		return updates, cancel
+	}
+
+	// 字符串值需要解码，解码失败的值被丢弃
+	out := make(chan interface{}, 1)
+	go func() {
+		defer close(out)
+		for value := range updates {
+			if s, ok := value.(string); ok {
+				decoded, err := c.decode(s)
+				if err != nil {
+					continue
+				}
+				value = decoded
+			}
+			select {
+			case <-out:
+			default:
+			}
+			out <- value
+		}
+	}()
+	return out, cancel
+}
+
+// GetAndDelete 原子地读取并删除键，按类型返回string、[]interface{}或map[string]interface{}
+func (c *LocalCache) GetAndDelete(key string) (interface{}, bool) {
+	obj, exists := c.engine.GetAndDelete(key)
+	if !exists {
+		return nil, false
+	}
+	return c.value(obj)
+}
+
+// value 按类型提取对象的值：string、[]interface{}（List与Set）或map[string]interface{}，字符串无法还原时返回false
+func (c *LocalCache) value(obj interfaces.DataObject) (interface{}, bool) {
+	switch obj.Type() {
+	case interfaces.DataTypeList:
+		return utils.ExtractListValue(obj)
+	case interfaces.DataTypeHash:
+		return utils.ExtractHashValue(obj)
+	case interfaces.DataTypeSet:
+		return utils.ExtractSetValue(obj)
+	default:
+		value, err := c.extractString(obj)
+		return value, err == nil
+	}
+}
+
+// MSet 在一次加锁内写入多个键，值按类型存储（string、[]interface{}、map[string]interface{}，其他类型按JSON存储）
+func (c *LocalCache) MSet(pairs map[string]interface{}, ttl ...time.Duration) error {
+	objs := make(map[string]interfaces.DataObject, len(pairs))
+	for key, value := range pairs {
+		obj, err := c.newObject(value, utils.ParseTTL(ttl))
+		if err != nil {
+			return err
+		}
+		objs[key] = obj
+	}
+	return c.engine.MSet(objs)
+}
+
+// SetNX 仅在键不存在或已过期时写入，返回是否写入，值按MSet的规则存储
+func (c *LocalCache) SetNX(key string, value interface{}, ttl ...time.Duration) (bool, error) {
+	return c.SetWithOptions(key, value, utils.ParseTTL(ttl), interfaces.SetOptions{NX: true})
+}
+
+// SetWithOptions 按NX/XX/KeepTTL条件写入，返回是否写入，值按MSet的规则存储
+func (c *LocalCache) SetWithOptions(key string, value interface{}, ttl time.Duration, opts interfaces.SetOptions) (bool, error) {
+	obj, err := c.newObject(value, ttl)
+	if err != nil {
+		return false, err
+	}
+	return c.engine.SetWithOptions(key, obj, opts)
+}
+
+// MGet 在一次加锁内读取多个键，结果与keys一一对应，不存在或已过期的键为nil
+func (c *LocalCache) MGet(keys ...string) []interface{} {
+	objs := c.engine.MGetTouch(keys)
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if obj, exists := objs[key]; exists {
+			if value, ok := c.value(obj); ok {
+				values[i] = value
+			}
+		}
+	}
+	return values
+}
+
+// GetStringAndDelete 原子地读取并删除字符串值，键不是字符串时不删除并返回false
+func (c *LocalCache) GetStringAndDelete(key string) (string, bool) {
+	obj, exists := c.engine.GetAndDelete(key, interfaces.DataTypeString)
+	if !exists {
+		return "", false
+	}
+
+	value, err := c.extractString(obj)
+	return value, err == nil
+}
+
+// RenameIf 仅当src的当前字符串值等于expected时原子地将其重命名为dst，返回是否重命名
+// 配置了ValueTransformer时比较的是转换后的值，要求Encode对相同输入输出相同结果
+func (c *LocalCache) RenameIf(src, dst, expected string) bool {
+	expected, err := c.encode(expected)
+	if err != nil {
+		return false
+	}
+	return c.engine.RenameIf(src, dst, expected)
+}
+
 // Exists Check if key exists
 func (c *LocalCache) Exists(key string) bool {
 	return c.engine.Exists(key)
@@ -123,6 +882,26 @@ func (c *LocalCache) Keys() []string {
 	return c.engine.Keys()
 }
 
+// KeysByType 返回指定类型的所有未过期键
+func (c *LocalCache) KeysByType(dt interfaces.DataType) []string {
+	return c.engine.KeysByType(dt)
+}
+
+// KeysMatch 返回匹配glob模式的未过期键（已排序），空模式返回全部键
+func (c *LocalCache) KeysMatch(pattern string) []string {
+	return c.engine.KeysMatch(pattern)
+}
+
+// Scan 按游标增量遍历键，返回下一次的游标和本次匹配的键，游标为0表示遍历结束
+func (c *LocalCache) Scan(cursor int, match string, count int) (int, []string) {
+	return c.engine.Scan(cursor, match, count)
+}
+
+// KeysPage 按键排序分页返回（page从1开始）
+func (c *LocalCache) KeysPage(page, pageSize int) types.KeyPage {
+	return types.PageKeys(c.engine.Keys(), page, pageSize)
+}
+
 // Flush 清空所有数据
 func (c *LocalCache) Flush() error {
 	return c.engine.Flush()
@@ -138,16 +917,87 @@ func (c *LocalCache) Expire(key string, ttl time.Duration) bool {
 	return c.engine.Expire(key, ttl)
 }
 
+// ExpireMatching 为所有匹配glob模式的键设置新的TTL，返回更新的键数
+func (c *LocalCache) ExpireMatching(pattern string, ttl time.Duration) int {
+	return c.engine.ExpireMatching(pattern, ttl)
+}
+
 // TTL 获取剩余生存时间
 func (c *LocalCache) TTL(key string) (time.Duration, bool) {
 	return c.engine.TTL(key)
 }
 
+// PTTL 获取剩余生存时间（毫秒），-1表示永不过期，-2表示键不存在
+func (c *LocalCache) PTTL(key string) int64 {
+	expiresAt, exists := c.engine.ExpireTime(key)
+	if !exists {
+		return constants.KeyMissing
+	}
+	if expiresAt.IsZero() {
+		return constants.NoExpiration
+	}
+
+	remaining := expiresAt.Sub(c.now()).Milliseconds()
+	if remaining < 0 {
+		return constants.KeyMissing
+	}
+	return remaining
+}
+
+// MTTL 按位置返回多个键的剩余生存时间（秒），-1表示永不过期，-2表示键不存在
+func (c *LocalCache) MTTL(keys ...string) []int {
+	return c.engine.MTTL(keys...)
+}
+
+// ExpireTime 获取过期时间的Unix时间戳（秒），-1表示永不过期，-2表示键不存在
+func (c *LocalCache) ExpireTime(key string) int64 {
+	expiresAt, exists := c.engine.ExpireTime(key)
+	if !exists {
+		return constants.KeyMissing
+	}
+	if expiresAt.IsZero() {
+		return constants.NoExpiration
+	}
+	return expiresAt.Unix()
+}
+
+// PExpireTime 获取过期时间的Unix时间戳（毫秒），-1表示永不过期，-2表示键不存在
+func (c *LocalCache) PExpireTime(key string) int64 {
+	expiresAt, exists := c.engine.ExpireTime(key)
+	if !exists {
+		return constants.KeyMissing
+	}
+	if expiresAt.IsZero() {
+		return constants.NoExpiration
+	}
+	return expiresAt.UnixMilli()
+}
+
 // Stats Get statistics
 func (c *LocalCache) Stats() interface{} {
 	return c.engine.Stats()
 }
 
+// HotKeys 返回最近window内访问最多的top个键，需配置EngineConfig.AccessLogSize，未启用时返回nil
+func (c *LocalCache) HotKeys(window time.Duration, top int) []types.KeyInfo {
+	return c.engine.HotKeys(window, top)
+}
+
+// Compact 压缩底层存储，回收大量删除后的内存
+func (c *LocalCache) Compact() {
+	c.engine.Compact()
+}
+
+// Export 流式导出所有数据
+func (c *LocalCache) Export(w io.Writer) error {
+	return c.engine.Export(w)
+}
+
+// Import 流式导入数据
+func (c *LocalCache) Import(r io.Reader) error {
+	return c.engine.Import(r)
+}
+
 // GetEngine 获取底层引擎（用于高级操作）
 func (c *LocalCache) GetEngine() interfaces.StorageEngine {
 	return c.engine
@@ -4,12 +4,16 @@ package {{.Package}}
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scache-io/scache"
 	"github.com/scache-io/scache/config"
 )
 
+// scacheDefaultTTL Default TTL used by StoreWithDefault
+const scacheDefaultTTL = {{.DefaultTTL}}
+
 {{range .Structs}}
 var (
 	default{{.Name}}Scache *{{.Name}}Scache
@@ -17,7 +21,8 @@ var (
 )
 
 type {{.Name}}Scache struct {
-	cache *scache.LocalCache
+	cache      *scache.LocalCache
+	defaultTTL atomic.Int64
 }
 
 func Get{{.Name}}Scache() *{{.Name}}Scache {
@@ -31,15 +36,29 @@ func New{{.Name}}Scache(cfg *config.EngineConfig) *{{.Name}}Scache {
 	if cfg == nil {
 		cfg = config.DefaultEngineConfig()
 	}
-	return &{{.Name}}Scache{
+	s := &{{.Name}}Scache{
 		cache: scache.New(cfg),
 	}
+	s.defaultTTL.Store(int64(scacheDefaultTTL))
+	return s
 }
 
 func (s *{{.Name}}Scache) Store(key string, obj {{.Name}}, ttl ...time.Duration) error {
 	return s.cache.Store(key, obj, ttl...)
 }
 
+func (s *{{.Name}}Scache) StoreWithDefault(key string, obj {{.Name}}) error {
+	return s.cache.Store(key, obj, s.DefaultTTL())
+}
+
+func (s *{{.Name}}Scache) DefaultTTL() time.Duration {
+	return time.Duration(s.defaultTTL.Load())
+}
+
+func (s *{{.Name}}Scache) SetDefaultTTL(ttl time.Duration) {
+	s.defaultTTL.Store(int64(ttl))
+}
+
 func (s *{{.Name}}Scache) Load(key string) ({{.Name}}, error) {
 	var obj {{.Name}}
 	err := s.cache.Load(key, &obj)
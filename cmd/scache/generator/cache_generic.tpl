@@ -4,12 +4,16 @@ package {{.Package}}
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scache-io/scache"
 	"github.com/scache-io/scache/config"
 )
 
+// scacheDefaultTTL Default TTL used by StoreWithDefault
+const scacheDefaultTTL = {{.DefaultTTL}}
+
 {{range .Structs}}
 var (
 	default{{.Name}}Scache *Scache[{{.Name}}]
@@ -18,7 +22,8 @@ var (
 {{end}}
 
 type Scache[T any] struct {
-	cache *scache.LocalCache
+	cache      *scache.LocalCache
+	defaultTTL atomic.Int64
 }
 
 {{range .Structs}}
@@ -38,15 +43,29 @@ func NewScache[T any](cfg *config.EngineConfig) *Scache[T] {
 	if cfg == nil {
 		cfg = config.DefaultEngineConfig()
 	}
-	return &Scache[T]{
+	s := &Scache[T]{
 		cache: scache.New(cfg),
 	}
+	s.defaultTTL.Store(int64(scacheDefaultTTL))
+	return s
 }
 
 func (s *Scache[T]) Store(key string, obj T, ttl ...time.Duration) error {
 	return s.cache.Store(key, obj, ttl...)
 }
 
+func (s *Scache[T]) StoreWithDefault(key string, obj T) error {
+	return s.cache.Store(key, obj, s.DefaultTTL())
+}
+
+func (s *Scache[T]) DefaultTTL() time.Duration {
+	return time.Duration(s.defaultTTL.Load())
+}
+
+func (s *Scache[T]) SetDefaultTTL(ttl time.Duration) {
+	s.defaultTTL.Store(int64(ttl))
+}
+
 func (s *Scache[T]) Load(key string) (T, error) {
 	var obj T
 	err := s.cache.Load(key, &obj)
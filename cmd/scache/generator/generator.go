@@ -9,8 +9,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 )
 
 //go:embed cache.tpl
@@ -28,6 +31,15 @@ type Config struct {
 	SplitPackages  bool     // Split by package
 	GeneratedCount int      // Number of generated structs
 	UseGeneric     bool     // Use generic version
+
+	IncludeUnexported bool // Also generate caches for unexported structs
+	OnlyTagged        bool // Only generate structs annotated with scache:"cache"
+
+	DefaultTTL time.Duration // Default TTL for generated StoreWithDefault (0 = no expiration)
+
+	GeneratedFiles   []string // Generated file paths (sorted)
+	GeneratedStructs []string // Generated struct names (sorted)
+	GeneratedPkgs    []string // Packages that received a generated file (sorted)
 }
 
 // StructInfo Struct information
@@ -38,6 +50,13 @@ type StructInfo struct {
 	Source string      // Source file path
 }
 
+// Struct annotation values, written in the struct doc comment (e.g. // scache:"cache")
+// or in a field tag (e.g. `scache:"-"`)
+const (
+	tagSkip  = "-"     // Skip struct or field
+	tagCache = "cache" // Opt struct in when OnlyTagged is set
+)
+
 // FieldInfo Field information
 type FieldInfo struct {
 	Name string // Field name
@@ -78,9 +97,22 @@ func Generate(config *Config) error {
 
 	// Record number of generated structs
 	config.GeneratedCount = len(structs)
+	config.GeneratedFiles = nil
+	config.GeneratedStructs = make([]string, 0, len(structs))
+	config.GeneratedPkgs = nil
+	for _, s := range structs {
+		config.GeneratedStructs = append(config.GeneratedStructs, s.Name)
+	}
+	sort.Strings(config.GeneratedStructs)
 
 	// Generate file in same directory, no package splitting
-	return generateInPlace(config, structs)
+	if err := generateInPlace(config, structs); err != nil {
+		return err
+	}
+
+	sort.Strings(config.GeneratedFiles)
+	sort.Strings(config.GeneratedPkgs)
+	return nil
 }
 
 // scanStructs Scan directoryall structs in
@@ -126,7 +158,7 @@ func scanStructs(config *Config) ([]StructInfo, error) {
 		}
 
 		// Extract structs
-		fileStructs := extractStructs(file, path)
+		fileStructs := extractStructs(file, path, config)
 		structs = append(structs, fileStructs...)
 
 		return nil
@@ -136,7 +168,7 @@ func scanStructs(config *Config) ([]StructInfo, error) {
 }
 
 // extractStructs Extract structs from AST
-func extractStructs(file *ast.File, sourcePath string) []StructInfo {
+func extractStructs(file *ast.File, sourcePath string, config *Config) []StructInfo {
 	var structs []StructInfo
 
 	for _, decl := range file.Decls {
@@ -156,6 +188,15 @@ func extractStructs(file *ast.File, sourcePath string) []StructInfo {
 				continue
 			}
 
+			// Apply visibility and annotation hints
+			if !config.IncludeUnexported && !typeSpec.Name.IsExported() {
+				continue
+			}
+			annotation := structAnnotation(genDecl, typeSpec)
+			if annotation == tagSkip || (config.OnlyTagged && annotation != tagCache) {
+				continue
+			}
+
 			// 提取Field information
 			var fields []FieldInfo
 			if structType.Fields != nil {
@@ -177,6 +218,9 @@ func extractStructs(file *ast.File, sourcePath string) []StructInfo {
 					if field.Tag != nil {
 						fieldInfo.Tag = strings.Trim(field.Tag.Value, "`")
 					}
+					if reflect.StructTag(fieldInfo.Tag).Get("scache") == tagSkip {
+						continue
+					}
 
 					fields = append(fields, fieldInfo)
 				}
@@ -194,6 +238,25 @@ func extractStructs(file *ast.File, sourcePath string) []StructInfo {
 	return structs
 }
 
+// structAnnotation Read scache:"..." annotation from struct doc comment
+func structAnnotation(genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) string {
+	doc := typeSpec.Doc
+	if doc == nil && len(genDecl.Specs) == 1 {
+		doc = genDecl.Doc
+	}
+	if doc == nil {
+		return ""
+	}
+
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if value, ok := reflect.StructTag(text).Lookup("scache"); ok {
+			return value
+		}
+	}
+	return ""
+}
+
 // fieldTypeToString Convert field type to string
 func fieldTypeToString(expr ast.Expr) string {
 	switch t := expr.(type) {
@@ -265,13 +328,19 @@ func generatePackageScache(config *Config, pkgName string, structs []StructInfo)
 	filename := filepath.Join(targetDir, pkgName+"_scache.go")
 
 	// Generate package code
-	content, err := generatePackageCode(pkgName, structs, config.UseGeneric)
+	content, err := generatePackageCode(pkgName, structs, config.UseGeneric, config.DefaultTTL)
 	if err != nil {
 		return fmt.Errorf("failed to generate code: %w", err)
 	}
 
 	// Write file
-	return generatePackageFile(filename, content)
+	if err := generatePackageFile(filename, content); err != nil {
+		return err
+	}
+
+	config.GeneratedFiles = append(config.GeneratedFiles, filename)
+	config.GeneratedPkgs = append(config.GeneratedPkgs, pkgName)
+	return nil
 }
 
 // findPackageDirectory Find package directory
@@ -286,8 +355,9 @@ func findPackageDirectory(pkgName string, structs []StructInfo) string {
 
 // TemplateData Template data structure
 type TemplateData struct {
-	Package string
-	Structs []StructInfo
+	Package    string
+	Structs    []StructInfo
+	DefaultTTL string // Go expression for the default TTL
 }
 
 // loadTemplate Load template file
@@ -304,7 +374,7 @@ func loadTemplate(useGeneric bool) (*template.Template, error) {
 }
 
 // generatePackageCode Generate cache code for specified package
-func generatePackageCode(pkgName string, structs []StructInfo, useGeneric bool) (string, error) {
+func generatePackageCode(pkgName string, structs []StructInfo, useGeneric bool, defaultTTL time.Duration) (string, error) {
 	// Load embedded template
 	tmpl, err := loadTemplate(useGeneric)
 	if err != nil {
@@ -312,8 +382,9 @@ func generatePackageCode(pkgName string, structs []StructInfo, useGeneric bool)
 	}
 
 	data := TemplateData{
-		Package: pkgName,
-		Structs: structs,
+		Package:    pkgName,
+		Structs:    structs,
+		DefaultTTL: durationLiteral(defaultTTL),
 	}
 
 	var buf strings.Builder
@@ -324,6 +395,30 @@ func generatePackageCode(pkgName string, structs []StructInfo, useGeneric bool)
 	return buf.String(), nil
 }
 
+// durationLiteral Render a duration as a readable Go expression, e.g. 5 * time.Minute
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+
+	if d <= 0 {
+		return "time.Duration(0)"
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
 // generatePackageFile Generate package file
 func generatePackageFile(filePath, content string) error {
 	// Ensure directory exists
@@ -2,7 +2,9 @@ package main
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -79,6 +81,10 @@ func newGenCmd() *cobra.Command {
 	cmd.Flags().StringP("exclude", "e", "vendor,node_modules,.git", "Exclude directories")
 	cmd.Flags().StringP("structs", "s", "", "Specific structs (comma-separated)")
 	cmd.Flags().BoolP("generic", "g", false, "Use generic version (Go 1.18+)")
+	cmd.Flags().Bool("json", false, "Print a machine-readable JSON summary")
+	cmd.Flags().Bool("include-unexported", false, "Also generate unexported structs")
+	cmd.Flags().Bool("only-tagged", false, "Only generate structs annotated with scache:\"cache\"")
+	cmd.Flags().Duration("ttl", 0, "Default TTL for StoreWithDefault (e.g. 5m, 0 = no expiration)")
 
 	return cmd
 }
@@ -102,6 +108,16 @@ func runGen(cmd *cobra.Command, args []string) error {
 	excludes, _ := cmd.Flags().GetString("exclude")
 	structs, _ := cmd.Flags().GetString("structs")
 	useGeneric, _ := cmd.Flags().GetBool("generic")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	includeUnexported, _ := cmd.Flags().GetBool("include-unexported")
+	onlyTagged, _ := cmd.Flags().GetBool("only-tagged")
+	defaultTTL, _ := cmd.Flags().GetDuration("ttl")
+
+	// Keep stdout clean for the JSON summary
+	progress := io.Writer(os.Stdout)
+	if jsonOutput {
+		progress = os.Stderr
+	}
 
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return fmt.Errorf("directory not found: %s", dir)
@@ -136,9 +152,13 @@ func runGen(cmd *cobra.Command, args []string) error {
 		TargetStructs: targetStructs,
 		SplitPackages: false,
 		UseGeneric:    useGeneric,
+
+		IncludeUnexported: includeUnexported,
+		OnlyTagged:        onlyTagged,
+		DefaultTTL:        defaultTTL,
 	}
 
-	if err := ensureScachePackage(dir); err != nil {
+	if err := ensureScachePackage(dir, progress); err != nil {
 		return err
 	}
 
@@ -155,18 +175,42 @@ func runGen(cmd *cobra.Command, args []string) error {
 	}
 
 	// Success output
+	if jsonOutput {
+		return printJSONSummary(config)
+	}
 	printSuccess(config, packageName, dir, targetStructs)
 	return nil
 }
 
+// genSummary Machine-readable gen result
+type genSummary struct {
+	Files       []string `json:"files"`
+	Structs     []string `json:"structs"`
+	Packages    []string `json:"packages"`
+	FileCount   int      `json:"file_count"`
+	StructCount int      `json:"struct_count"`
+}
+
+func printJSONSummary(config *generator.Config) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(genSummary{
+		Files:       config.GeneratedFiles,
+		Structs:     config.GeneratedStructs,
+		Packages:    config.GeneratedPkgs,
+		FileCount:   len(config.GeneratedFiles),
+		StructCount: config.GeneratedCount,
+	})
+}
+
 func printSuccess(config *generator.Config, packageName, dir string, targetStructs []string) {
 	fmt.Printf("%s✓%s Generated %d struct(s): %s\n", colorGreen, colorReset, config.GeneratedCount, dir)
 }
 
-func ensureScachePackage(dir string) error {
+func ensureScachePackage(dir string, progress io.Writer) error {
 	projectRoot, err := findProjectRoot(dir)
 	if err != nil {
-		fmt.Printf("%s→%s Initializing go.mod...\n", colorCyan, colorReset)
+		fmt.Fprintf(progress, "%s→%s Initializing go.mod...\n", colorCyan, colorReset)
 		if err := initGoMod(dir); err != nil {
 			return err
 		}
@@ -174,7 +218,7 @@ func ensureScachePackage(dir string) error {
 	}
 
 	if !isScachePackageInstalled(projectRoot) {
-		fmt.Printf("%s→%s Installing scache...\n", colorCyan, colorReset)
+		fmt.Fprintf(progress, "%s→%s Installing scache...\n", colorCyan, colorReset)
 		if err := installScachePackage(projectRoot); err != nil {
 			return err
 		}
@@ -1,17 +1,82 @@
 package config
 
 import (
+	"fmt"
+	"path"
 	"time"
 
 	"github.com/scache-io/scache/constants"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/pkg/workerpool"
+	"github.com/scache-io/scache/utils"
 )
 
+// PolicyFactory 按容量创建淘汰策略
+type PolicyFactory func(capacity int) interfaces.EvictionPolicy
+
+// EvictCallback 键因容量不足被淘汰时的回调，obj为被淘汰的对象
+type EvictCallback func(key string, obj interfaces.DataObject)
+
+// ExpireCallback 后台清理发现过期键时的回调，返回替换对象和true时用新对象刷新键，返回false时删除键
+type ExpireCallback func(key string, old interfaces.DataObject) (interfaces.DataObject, bool)
+
+// AdmissionFilter 写入准入过滤，返回false时新键的写入被跳过
+type AdmissionFilter func(key string) bool
+
+// BatchOverflow 批量写入超过MaxBatchSize时的处理方式
+type BatchOverflow int
+
+const (
+	BatchReject BatchOverflow = iota // 整批拒绝，不写入任何键
+	BatchChunk                       // 按MaxBatchSize分块写入，每块单独加锁
+)
+
+// ValueTransformer 字符串/结构体值的双向转换（如加密），写入时Encode，读取时Decode
+type ValueTransformer struct {
+	Encode func([]byte) ([]byte, error)
+	Decode func([]byte) ([]byte, error)
+}
+
 // EngineConfig Storage engine配置
 type EngineConfig struct {
-	MaxSize                   int           // 最大缓存数量
-	MemoryThreshold           float64       // 内存阈值
-	DefaultExpiration         time.Duration // 默认过期时间
-	BackgroundCleanupInterval time.Duration // 后台清理间隔
+	MaxSize                   int                            // 最大缓存数量
+	MemoryThreshold           float64                        // 内存阈值
+	DefaultExpiration         time.Duration                  // 默认过期时间
+	BackgroundCleanupInterval time.Duration                  // 后台清理间隔，0表示不启动清理goroutine，过期键只在访问时惰性删除
+	CompactThreshold          float64                        // 自动压缩阈值（存活键数/峰值键数低于该比例时重建map），0表示禁用
+	KeyNormalizer             func(string) string            // 键规范化函数（如strings.ToLower），nil表示不处理
+	IdleTimeout               time.Duration                  // 闲置超时，距最后访问超过该时长视为过期，0表示禁用
+	OnFull                    func(currentSize, maxSize int) // 首次达到MaxSize时回调（降到容量以下后再次填满会重新触发）
+	StatsWindow               time.Duration                  // 窗口统计周期，Stats中的window_hit_rate反映最近一到两个窗口，0表示禁用
+	PolicyFactory             PolicyFactory                  // 淘汰策略工厂，nil表示使用LRU
+	WorkerPool                *workerpool.Pool               // 共享后台任务池，多个引擎的清理任务在其中执行，nil表示每个引擎启动独立goroutine
+	MinTTL                    time.Duration                  // TTL下限，低于该值的正TTL会被提升到下限（永久键不受影响），0表示禁用
+	RejectBelowMinTTL         bool                           // 为true时低于MinTTL的写入返回ErrTTLBelowMinimum而不是提升
+	UseJSONNumber             bool                           // Load/GetStruct/Update解码到interface{}时使用json.Number保留整数精度，而不是float64
+	SoftLimitRatio            float64                        // 软限制比例（相对MaxSize），键数达到该比例时触发OnSoftLimit，0表示禁用
+	OnSoftLimit               func(currentSize, maxSize int) // 键数越过软限制时回调，回落到阈值以下一定比例后再次越过会重新触发
+	AccessLogSize             int                            // 访问日志环形缓冲区大小（记录最近N次读取的键和时间，用于HotKeys），0表示禁用
+	AccessLogSampleEvery      int                            // 访问日志采样间隔，每N次读取记录一次以控制开销，0或1表示全部记录
+	OnEvict                   EvictCallback                  // 键被淘汰时回调（在释放锁之后执行，可用于溢出到二级存储），nil表示不回调
+	AdaptiveCleanup           bool                           // 自适应清理间隔：回收多时缩短、回收少时延长，从BackgroundCleanupInterval开始
+	MinCleanupInterval        time.Duration                  // 自适应清理间隔下限，0表示BackgroundCleanupInterval的1/10
+	MaxCleanupInterval        time.Duration                  // 自适应清理间隔上限，0表示BackgroundCleanupInterval的10倍
+	ValueTransformer          *ValueTransformer              // LocalCache字符串/结构体值的转换（如静态加密），键保持明文，nil表示不转换
+	CleanupJitter             time.Duration                  // 首次清理前随机延迟的上限，错开同时创建的多个引擎的清理时间，0表示清理间隔的10%
+	AdmissionFilter           AdmissionFilter                // 新键写入前的准入检查（如布隆过滤器判断是否见过），返回false时跳过写入但Set仍返回nil；持有写锁调用，不能访问引擎，nil表示全部准入
+	TwoPhaseDelete            bool                           // 两阶段删除：读路径发现过期键时只原子标记，由后台清理批量删除，读路径不获取写锁；未启用后台清理时仍立即删除
+	SlidingTTLPatterns        []string                       // 滑动过期的键模式（path.Match语法，如session:*），匹配的键每次读取命中时过期时间重置为写入时的TTL
+	MaxBatchSize              int                            // 单次批量写入（MSet）的最大键数，0表示不限制
+	BatchOverflow             BatchOverflow                  // 批量写入超过MaxBatchSize时的处理方式：BatchReject整批拒绝，BatchChunk分块写入
+	PersistPath               string                         // LocalCache关闭时将未过期数据（含过期时间）导出到该文件，创建时从该文件恢复，空表示不持久化
+	OnRestoreError            func(path string, err error)   // 创建LocalCache时从PersistPath恢复失败（文件损坏、版本未知或无法读取）时回调，此时关闭时不会覆盖该文件；文件不存在不视为错误
+	OnExpire                  ExpireCallback                 // 后台清理发现过期键时回调（在释放锁之后执行），可返回新对象刷新键而不是删除；读取时发现的过期键仍直接删除，需启用后台清理
+	MaxMemoryBytes            int64                          // 估算内存上限（字节，按对象Size统计），写入后超过MemoryThreshold*MaxMemoryBytes时按淘汰策略循环淘汰，0表示禁用
+	LoaderTimeout             time.Duration                  // GetOrStore加载超时，超时后等待者收到ErrLoaderTimeout，loader在后台继续执行且结果被丢弃，0表示不限制
+	DeterministicEviction     bool                           // 确定性淘汰（用于可复现的测试）：MSet按键排序写入、后台清理按键排序处理过期键，使淘汰顺序不依赖map遍历顺序
+	Clock                     func() time.Time               // 引擎计算过期时间与剩余TTL、判断过期与闲置超时、轮换窗口统计使用的时钟（测试中可注入可控时钟），nil表示time.Now
+	StrictPolicyAccess        bool                           // 策略严格访问模式：读取不为已删除的键创建策略条目，保证策略键是存储键的子集（需策略实现StrictAccessPolicy）
+	Shards                    int                            // 分片数（2的幂），大于1时键按FNV哈希分布到各自加锁、各自淘汰的分片以降低锁竞争，MaxSize/MaxMemoryBytes按分片均分；跨分片的多键操作不再是原子的，淘汰只在分片内按LRU进行；0或1表示不分片
 }
 
 // DefaultEngineConfig 默认引擎配置
@@ -21,5 +86,94 @@ func DefaultEngineConfig() *EngineConfig {
 		MemoryThreshold:           constants.DefaultMemoryThreshold, // 80%
 		DefaultExpiration:         constants.DefaultExpiration,      // 永不过期
 		BackgroundCleanupInterval: constants.DefaultCleanupInterval, // 禁用自动清理
+		Shards:                    constants.DefaultShards,          // 不分片
+	}
+}
+
+// Validate 校验配置取值范围
+func (c *EngineConfig) Validate() error {
+	checks := []error{
+		utils.ValidateCapacity(c.MaxSize),
+		utils.ValidateMemoryThreshold(c.MemoryThreshold),
+		utils.ValidateRatio("compact threshold", c.CompactThreshold),
+		utils.ValidateRatio("soft limit ratio", c.SoftLimitRatio),
+		utils.ValidateDuration("default expiration", c.DefaultExpiration),
+		utils.ValidateDuration("background cleanup interval", c.BackgroundCleanupInterval),
+		utils.ValidateDuration("idle timeout", c.IdleTimeout),
+		utils.ValidateDuration("stats window", c.StatsWindow),
+		utils.ValidateDuration("min ttl", c.MinTTL),
+		utils.ValidateDuration("min cleanup interval", c.MinCleanupInterval),
+		utils.ValidateDuration("max cleanup interval", c.MaxCleanupInterval),
+		utils.ValidateDuration("cleanup jitter", c.CleanupJitter),
+		utils.ValidateDuration("loader timeout", c.LoaderTimeout),
+		utils.ValidateCount("access log size", c.AccessLogSize),
+		utils.ValidateCount("access log sample interval", c.AccessLogSampleEvery),
+		utils.ValidateCount("max batch size", c.MaxBatchSize),
+	}
+
+	for _, err := range checks {
+		if err != nil {
+			return fmt.Errorf("invalid engine config: %w", err)
+		}
+	}
+
+	if c.MinCleanupInterval > 0 && c.MaxCleanupInterval > 0 && c.MinCleanupInterval > c.MaxCleanupInterval {
+		return fmt.Errorf("invalid engine config: invalid argument: min cleanup interval must not exceed max cleanup interval")
+	}
+	if c.ValueTransformer != nil && (c.ValueTransformer.Encode == nil || c.ValueTransformer.Decode == nil) {
+		return fmt.Errorf("invalid engine config: invalid argument: value transformer requires both encode and decode")
+	}
+	if c.MaxMemoryBytes < 0 {
+		return fmt.Errorf("invalid engine config: invalid argument: max memory bytes must be non-negative")
+	}
+	if c.Shards < 0 || c.Shards > constants.MaxShards || c.Shards&(c.Shards-1) != 0 {
+		return fmt.Errorf("invalid engine config: invalid argument: shards must be a power of two no greater than %d, got %d", constants.MaxShards, c.Shards)
+	}
+	if c.Shards > 1 && c.MaxSize > 0 && c.MaxSize < c.Shards {
+		return fmt.Errorf("invalid engine config: invalid argument: max size %d is smaller than shards %d", c.MaxSize, c.Shards)
+	}
+	if c.BatchOverflow != BatchReject && c.BatchOverflow != BatchChunk {
+		return fmt.Errorf("invalid engine config: invalid argument: unknown batch overflow mode %d", c.BatchOverflow)
+	}
+	for _, pattern := range c.SlidingTTLPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid engine config: invalid argument: sliding ttl pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// WithValueTransformer 设置值转换函数并返回配置本身，便于链式调用
+func (c *EngineConfig) WithValueTransformer(encode, decode func([]byte) ([]byte, error)) *EngineConfig {
+	c.ValueTransformer = &ValueTransformer{Encode: encode, Decode: decode}
+	return c
+}
+
+// WithPersistOnClose 设置关闭时持久化的文件路径并返回配置本身，便于链式调用
+func (c *EngineConfig) WithPersistOnClose(path string) *EngineConfig {
+	c.PersistPath = path
+	return c
+}
+
+// CleanupJitterRange 返回首次清理随机延迟的上限，未配置时为清理间隔的DefaultCleanupJitterRatio
+func (c *EngineConfig) CleanupJitterRange() time.Duration {
+	if c.CleanupJitter > 0 {
+		return c.CleanupJitter
+	}
+	return time.Duration(float64(c.BackgroundCleanupInterval) * constants.DefaultCleanupJitterRatio)
+}
+
+// CleanupIntervalBounds 返回自适应清理间隔的上下限，未配置时按BackgroundCleanupInterval推导
+func (c *EngineConfig) CleanupIntervalBounds() (time.Duration, time.Duration) {
+	minInterval, maxInterval := c.MinCleanupInterval, c.MaxCleanupInterval
+	if minInterval <= 0 {
+		minInterval = c.BackgroundCleanupInterval / constants.AdaptiveCleanupBoundFactor
+	}
+	if maxInterval <= 0 {
+		maxInterval = c.BackgroundCleanupInterval * constants.AdaptiveCleanupBoundFactor
+	}
+	if minInterval <= 0 {
+		minInterval = 1
 	}
+	return minInterval, max(minInterval, maxInterval)
 }
@@ -9,6 +9,36 @@ const (
 	DefaultCleanupInterval = 0    // 默认清理间隔，0表示不执行清理
 	DefaultInitialCapacity = 16   // 默认初始容量
 	DefaultStatsEnabled    = true // 默认启用统计功能
+	DefaultShards          = 1    // 默认分片数，1表示单锁引擎；需求中的默认16待多核基准确认，见BENCHMARKS.md
+	MaxShards              = 4096 // 分片数上限
+)
+
+// map压缩Constant
+const (
+	MinCompactSize = 1024 // 触发自动压缩的最小峰值键数，避免小map频繁重建
+)
+
+// 自适应清理Constant
+const (
+	AdaptiveCleanupShrinkRatio = 0.25 // 一轮清理回收的键占比不低于该值时间隔减半
+	AdaptiveCleanupGrowRatio   = 0.01 // 一轮清理回收的键占比不高于该值时间隔加倍
+	AdaptiveCleanupBoundFactor = 10   // 未配置上下限时，以BackgroundCleanupInterval的1/10和10倍作为默认上下限
+)
+
+// 清理抖动Constant
+const (
+	DefaultCleanupJitterRatio = 0.1 // 未配置CleanupJitter时，首次清理的随机延迟上限为清理间隔的10%
+)
+
+// 软限制Constant
+const (
+	SoftLimitHysteresis = 0.05 // 软限制回落比例，键数降到阈值减去MaxSize的5%（至少1个）以下后才会再次触发
+)
+
+// 过期时间查询返回值Constant（与Redis约定一致）
+const (
+	NoExpiration = -1 // 键存在但永不过期
+	KeyMissing   = -2 // 键不存在
 )
 
 // DefaultLRUCapacity LRU策略默认配置
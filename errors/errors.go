@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error定义
 var (
@@ -24,4 +27,41 @@ var (
 
 	// ErrListEmpty 列表为空Error
 	ErrListEmpty = errors.New("list is empty")
+
+	// ErrGlobalInitialized 全局缓存已初始化Error
+	ErrGlobalInitialized = errors.New("global cache already initialized")
+
+	// ErrTTLBelowMinimum TTL低于配置的最小值Error
+	ErrTTLBelowMinimum = errors.New("ttl below minimum")
+
+	// ErrCacheClosed 缓存已关闭Error
+	ErrCacheClosed = errors.New("cache closed")
+
+	// ErrLoadShed 并发加载数已达上限被拒绝Error
+	ErrLoadShed = errors.New("load shed: too many concurrent loads")
+
+	// ErrLoaderTimeout 加载函数未在LoaderTimeout内返回Error
+	ErrLoaderTimeout = errors.New("loader timeout")
+
+	// ErrLoaderPanicked 共享的加载函数panic，等待者未得到结果Error
+	ErrLoaderPanicked = errors.New("loader panicked")
+
+	// ErrBatchTooLarge 批量操作超过MaxBatchSize被拒绝Error
+	ErrBatchTooLarge = errors.New("batch too large")
 )
+
+// BatchError 批量写入部分或全部失败，Rejected为未写入的键（已排序），Err为失败原因
+type BatchError struct {
+	Rejected []string
+	Err      error
+}
+
+// Error 实现error接口
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch rejected %d keys: %v", len(e.Rejected), e.Err)
+}
+
+// Unwrap 返回失败原因，支持errors.Is
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,9 @@
 package interfaces
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 // DataType Data type枚举
 type DataType string
@@ -9,14 +12,40 @@ const (
 	DataTypeString DataType = "string"
 	DataTypeList   DataType = "list"
 	DataTypeHash   DataType = "hash"
+	DataTypeSet    DataType = "set"
 	DataTypeStruct DataType = "struct"
 )
 
+// ListEnd 列表的一端，用于LMove
+type ListEnd int
+
+const (
+	ListLeft  ListEnd = iota // 列表头部
+	ListRight                // 列表尾部
+)
+
+// MergeStrategy 合并两个引擎时的冲突处理策略
+type MergeStrategy int
+
+const (
+	MergeOverwrite    MergeStrategy = iota // 冲突时使用来源引擎的值
+	MergeKeepExisting                      // 冲突时保留当前引擎的值
+	MergeNewestWins                        // 冲突时保留最后修改时间较新的值（写入或原地修改）
+)
+
+// SetOptions 条件写入选项
+type SetOptions struct {
+	NX      bool // 仅在键不存在（或已过期）时写入
+	XX      bool // 仅在键存在时写入
+	KeepTTL bool // 键存在时沿用原对象的过期时间
+}
+
 // DataObject Generic data object interface
 type DataObject interface {
 	Type() DataType
 	ExpiresAt() time.Time
 	IsExpired() bool
+	// Size 返回对象的字节大小：字符串为字节长度，List/Hash为元素序列化后的长度之和，引擎据此统计内存
 	Size() int
 }
 
@@ -33,6 +62,8 @@ type ListObject interface {
 	Values() []interface{}
 	Push(value interface{})
 	Pop() (interface{}, bool)
+	PushFront(value interface{})
+	PopFront() (interface{}, bool)
 	Index(index int) (interface{}, bool)
 	Range(start, end int) []interface{}
 	Len() int
@@ -48,6 +79,16 @@ type HashObject interface {
 	Len() int
 }
 
+// SetObject Set object interface，成员必须是可比较（可作为map键）的值
+type SetObject interface {
+	DataObject
+	Add(member interface{}) bool
+	Remove(member interface{}) bool
+	Contains(member interface{}) bool
+	Members() []interface{}
+	Len() int
+}
+
 // StructObject Struct object interface
 type StructObject interface {
 	DataObject
@@ -58,10 +99,20 @@ type StructObject interface {
 // StorageEngine Storage engineInterface
 type StorageEngine interface {
 	Set(key string, obj DataObject) error
+	MSet(objs map[string]DataObject) error
+	SetNX(key string, obj DataObject) (bool, error)
+	SetWithOptions(key string, obj DataObject, opts SetOptions) (bool, error)
 	Get(key string) (DataObject, bool)
 	Delete(key string) bool
+	DeleteMany(keys []string) (int, map[string]bool)
+	GetAndDelete(key string, dataTypes ...DataType) (DataObject, bool)
+	MGetTouch(keys []string) map[string]DataObject
+	RenameIf(src, dst, expected string) bool
 	Exists(key string) bool
 	Keys() []string
+	KeysByType(dt DataType) []string
+	KeysMatch(pattern string) []string
+	Scan(cursor int, match string, count int) (int, []string)
 	Flush() error
 	Size() int
 
@@ -71,9 +122,62 @@ type StorageEngine interface {
 	// Expire 过期管理
 	Expire(key string, ttl time.Duration) bool
 	TTL(key string) (time.Duration, bool)
+	ExpireTime(key string) (time.Time, bool)
+	MTTL(keys ...string) []int
+	ExpireMatching(pattern string, ttl time.Duration) int
+
+	// IncrBy 原子地对整数字符串值加上delta
+	IncrBy(key string, delta int64) (int64, error)
+
+	// Hash字段操作与二级索引
+	HSet(key, field string, value interface{}) error
+	HDel(key, field string) bool
+	HDelMany(key string, fields ...string) int
+	CreateIndex(keyPattern, field string) error
+	LookupIndex(field string, value interface{}) []string
+
+	// Set成员操作，返回新增/移除的成员数
+	SAdd(key string, members ...interface{}) (int, error)
+	SRem(key string, members ...interface{}) (int, error)
+
+	// 容量预留
+	Reserve(key string) error
+	CancelReservation(key string) bool
+
+	// List两端操作，Push返回写入后的长度，Pop取出最后一个元素时删除键
+	LPush(key string, values ...interface{}) (int, error)
+	RPush(key string, values ...interface{}) (int, error)
+	LPop(key string) (interface{}, bool)
+	RPop(key string) (interface{}, bool)
+	LMove(src, dst string, srcEnd, dstEnd ListEnd) (interface{}, error)
+
+	// 固定容量环形列表
+	LPushCap(key string, capacity int, value interface{}) error
+	RPushCap(key string, capacity int, value interface{}) error
+
+	// Watch 监听键的变化，键被删除或过期移除时关闭通道
+	Watch(key string) (<-chan interface{}, func())
 
 	// Stats 统计信息
 	Stats() interface{}
+
+	// HotKeys 返回最近window内访问最多的top个键，未启用访问日志时返回nil
+	HotKeys(window time.Duration, top int) []KeyInfo
+
+	// Diff/Merge 增量同步
+	Diff(other StorageEngine) (added, changed, removed []string)
+	Merge(other StorageEngine, strategy MergeStrategy) (int, error)
+
+	// Compact 重建底层存储以回收内存
+	Compact()
+
+	// Export/Import 流式导出/导入所有数据
+	Export(w io.Writer) error
+	Import(r io.Reader) error
+
+	// Close 关闭引擎，之后的操作返回ErrCacheClosed
+	Close() error
+	Closed() bool
 }
 
 // EvictionPolicy Eviction policyInterface
@@ -104,4 +208,40 @@ type EvictionPolicy interface {
 
 	// UpdateCapacity 更新容量限制
 	UpdateCapacity(newCapacity int)
+
+	// Stats 策略运行统计
+	Stats() PolicyStats
+}
+
+// NamedPolicy 可报告名称的淘汰策略（可选实现），名称出现在引擎Stats的policy_name中
+type NamedPolicy interface {
+	Name() string
+}
+
+// PolicyStats 淘汰策略统计信息
+type PolicyStats struct {
+	Operations int64     `json:"operations"`   // Access/Set/Delete/Evict调用次数
+	Evictions  int64     `json:"evictions"`    // 实际淘汰的键数
+	LastOpTime time.Time `json:"last_op_time"` // 最近一次操作时间
+}
+
+// KeyInfo 热点键统计
+type KeyInfo struct {
+	Key        string    `json:"key"`
+	Accesses   int64     `json:"accesses"`    // 窗口内采样到的访问次数
+	LastAccess time.Time `json:"last_access"` // 窗口内最后一次采样到的访问时间
+}
+
+// ExpiryAwarePolicy 需要感知键过期时间的淘汰策略（可选实现）
+// 引擎在写入键或修改过期时间后调用SetExpiry，零值表示永不过期
+type ExpiryAwarePolicy interface {
+	EvictionPolicy
+	SetExpiry(key string, expiresAt time.Time)
+}
+
+// StrictAccessPolicy 支持严格访问模式的淘汰策略（可选实现）
+// 严格模式下Access不为未知键创建条目，策略中的键始终是存储键的子集
+type StrictAccessPolicy interface {
+	EvictionPolicy
+	SetStrictAccess(strict bool)
 }
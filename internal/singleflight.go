@@ -0,0 +1,44 @@
+package internal
+
+import "sync"
+
+// call 正在进行或已完成的一次调用
+type call struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+// Group 合并同一键的并发调用，保证同一时刻每个键只执行一次fn
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// Do 执行fn并返回结果；同一键已有调用在进行时等待并共享其结果，shared表示结果是否来自其他调用
+func (g *Group) Do(key string, fn func() (interface{}, error)) (val interface{}, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+
+	c := &call{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+	return c.val, c.err, false
+}
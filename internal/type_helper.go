@@ -23,7 +23,7 @@ func ExtractListValue(obj interfaces.DataObject) ([]interface{}, bool) {
 		return nil, false
 	}
 
-	if listObj, ok := obj.(*types.ListObject); ok {
+	if listObj, ok := obj.(interfaces.ListObject); ok {
 		return listObj.Values(), true
 	}
 	return nil, false
@@ -7,6 +7,8 @@ import (
 	"github.com/scache-io/scache/cache"
 	"github.com/scache-io/scache/config"
 	"github.com/scache-io/scache/constants"
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
 )
 
 // LocalCache Local cache wrapper的别名，方便外部使用
@@ -23,17 +25,20 @@ var (
 	globalOnce  sync.Once
 )
 
+// defaultGlobalConfig 全局缓存默认使用中等配置
+func defaultGlobalConfig() *config.EngineConfig {
+	return &config.EngineConfig{
+		MaxSize:                   constants.MediumCapacity,
+		MemoryThreshold:           constants.MediumMemoryThreshold,
+		DefaultExpiration:         constants.TwoHours,
+		BackgroundCleanupInterval: constants.TenMinutes,
+	}
+}
+
 // GetGlobalCache 获取全局缓存实例（线程安全）
 func GetGlobalCache() *LocalCache {
 	globalOnce.Do(func() {
-		// 创建中等配置
-		mediumConfig := &config.EngineConfig{
-			MaxSize:                   constants.MediumCapacity,
-			MemoryThreshold:           constants.MediumMemoryThreshold,
-			DefaultExpiration:         constants.TwoHours,
-			BackgroundCleanupInterval: constants.TenMinutes,
-		}
-		globalCache = New(mediumConfig)
+		globalCache = New(defaultGlobalConfig())
 	})
 	return globalCache
 }
@@ -45,6 +50,29 @@ func InitGlobalCache(engineConfig *config.EngineConfig) {
 	})
 }
 
+// ConfigureGlobal 自定义全局缓存配置，必须在首次使用全局缓存前调用，否则返回ErrGlobalInitialized
+func ConfigureGlobal(engineConfig *config.EngineConfig) error {
+	if engineConfig != nil {
+		if err := engineConfig.Validate(); err != nil {
+			return err
+		}
+	}
+
+	configured := false
+	globalOnce.Do(func() {
+		if engineConfig == nil {
+			engineConfig = defaultGlobalConfig()
+		}
+		globalCache = New(engineConfig)
+		configured = true
+	})
+
+	if !configured {
+		return errors.ErrGlobalInitialized
+	}
+	return nil
+}
+
 // SetString 全局Set string value
 func SetString(key, value string, ttl ...time.Duration) error {
 	return GetGlobalCache().SetString(key, value, ttl...)
@@ -65,6 +93,41 @@ func GetList(key string) ([]interface{}, bool) {
 	return GetGlobalCache().GetList(key)
 }
 
+// LPush 全局在列表头部写入
+func LPush(key string, values ...interface{}) (int, error) {
+	return GetGlobalCache().LPush(key, values...)
+}
+
+// RPush 全局在列表尾部写入
+func RPush(key string, values ...interface{}) (int, error) {
+	return GetGlobalCache().RPush(key, values...)
+}
+
+// LPop 全局移除并返回列表头部元素
+func LPop(key string) (interface{}, bool) {
+	return GetGlobalCache().LPop(key)
+}
+
+// RPop 全局移除并返回列表尾部元素
+func RPop(key string) (interface{}, bool) {
+	return GetGlobalCache().RPop(key)
+}
+
+// LMove 全局在两个列表之间原子移动元素
+func LMove(src, dst string, srcEnd, dstEnd interfaces.ListEnd) (interface{}, error) {
+	return GetGlobalCache().LMove(src, dst, srcEnd, dstEnd)
+}
+
+// LLen 全局获取列表长度
+func LLen(key string) int {
+	return GetGlobalCache().LLen(key)
+}
+
+// LRange 全局获取列表区间内的元素
+func LRange(key string, start, end int) []interface{} {
+	return GetGlobalCache().LRange(key, start, end)
+}
+
 // SetHash 全局Set hash value
 func SetHash(key string, fields map[string]interface{}, ttl ...time.Duration) error {
 	return GetGlobalCache().SetHash(key, fields, ttl...)
@@ -75,6 +138,71 @@ func GetHash(key string) (map[string]interface{}, bool) {
 	return GetGlobalCache().GetHash(key)
 }
 
+// HSet 全局设置Hash字段
+func HSet(key, field string, value interface{}) error {
+	return GetGlobalCache().HSet(key, field, value)
+}
+
+// HDel 全局删除Hash字段
+func HDel(key, field string) bool {
+	return GetGlobalCache().HDel(key, field)
+}
+
+// HDelMany 全局删除多个Hash字段
+func HDelMany(key string, fields ...string) int {
+	return GetGlobalCache().HDelMany(key, fields...)
+}
+
+// HGetAll 全局获取Hash的所有字段
+func HGetAll(key string) (map[string]interface{}, bool) {
+	return GetGlobalCache().HGetAll(key)
+}
+
+// HKeys 全局获取Hash的所有字段名
+func HKeys(key string) ([]string, bool) {
+	return GetGlobalCache().HKeys(key)
+}
+
+// HVals 全局获取Hash的所有字段值
+func HVals(key string) ([]interface{}, bool) {
+	return GetGlobalCache().HVals(key)
+}
+
+// HLen 全局获取Hash的字段数
+func HLen(key string) int {
+	return GetGlobalCache().HLen(key)
+}
+
+// HExists 全局检查Hash字段是否存在
+func HExists(key, field string) bool {
+	return GetGlobalCache().HExists(key, field)
+}
+
+// SAdd 全局向Set添加成员
+func SAdd(key string, members ...interface{}) (int, error) {
+	return GetGlobalCache().SAdd(key, members...)
+}
+
+// SRem 全局从Set移除成员
+func SRem(key string, members ...interface{}) (int, error) {
+	return GetGlobalCache().SRem(key, members...)
+}
+
+// SMembers 全局获取Set的所有成员
+func SMembers(key string) ([]interface{}, bool) {
+	return GetGlobalCache().SMembers(key)
+}
+
+// SIsMember 全局检查Set成员
+func SIsMember(key string, member interface{}) bool {
+	return GetGlobalCache().SIsMember(key, member)
+}
+
+// SCard 全局获取Set的成员数
+func SCard(key string) int {
+	return GetGlobalCache().SCard(key)
+}
+
 // Store 全局Store struct值（JSON序列化，支持指针和非指针Type）
 func Store(key string, obj interface{}, ttl ...time.Duration) error {
 	return GetGlobalCache().Store(key, obj, ttl...)
@@ -85,11 +213,76 @@ func Load(key string, dest interface{}) error {
 	return GetGlobalCache().Load(key, dest)
 }
 
+// GetStringE 全局获取字符串值，类型不匹配时返回ErrTypeMismatch
+func GetStringE(key string) (string, bool, error) {
+	return GetGlobalCache().GetStringE(key)
+}
+
+// GetInt 全局获取整数值
+func GetInt(key string) (int, bool, error) {
+	return GetGlobalCache().GetInt(key)
+}
+
+// GetStruct 全局按类型获取结构体值
+func GetStruct[T any](key string) (T, bool, error) {
+	return cache.GetStruct[T](GetGlobalCache(), key)
+}
+
 // Delete 全局Delete key
 func Delete(key string) bool {
 	return GetGlobalCache().Delete(key)
 }
 
+// DeleteMany 全局批量删除键
+func DeleteMany(keys ...string) (int, map[string]bool) {
+	return GetGlobalCache().DeleteMany(keys...)
+}
+
+// SetNX 全局仅在键不存在时写入
+func SetNX(key string, value interface{}, ttl ...time.Duration) (bool, error) {
+	return GetGlobalCache().SetNX(key, value, ttl...)
+}
+
+// MSet 全局批量写入
+func MSet(pairs map[string]interface{}, ttl ...time.Duration) error {
+	return GetGlobalCache().MSet(pairs, ttl...)
+}
+
+// MGet 全局批量读取
+func MGet(keys ...string) []interface{} {
+	return GetGlobalCache().MGet(keys...)
+}
+
+// Incr 全局原子加1
+func Incr(key string) (int64, error) {
+	return GetGlobalCache().Incr(key)
+}
+
+// Decr 全局原子减1
+func Decr(key string) (int64, error) {
+	return GetGlobalCache().Decr(key)
+}
+
+// IncrBy 全局原子加上delta
+func IncrBy(key string, delta int64) (int64, error) {
+	return GetGlobalCache().IncrBy(key, delta)
+}
+
+// Observe 全局监听键的变化
+func Observe(key string) (<-chan interface{}, func()) {
+	return GetGlobalCache().Observe(key)
+}
+
+// GetAndDelete 全局原子读取并删除键
+func GetAndDelete(key string) (interface{}, bool) {
+	return GetGlobalCache().GetAndDelete(key)
+}
+
+// GetStringAndDelete 全局原子读取并删除字符串值
+func GetStringAndDelete(key string) (string, bool) {
+	return GetGlobalCache().GetStringAndDelete(key)
+}
+
 // Exists 全局Check if key exists
 func Exists(key string) bool {
 	return GetGlobalCache().Exists(key)
@@ -100,6 +293,21 @@ func Keys() []string {
 	return GetGlobalCache().Keys()
 }
 
+// KeysByType 全局获取指定类型的所有键
+func KeysByType(dt interfaces.DataType) []string {
+	return GetGlobalCache().KeysByType(dt)
+}
+
+// Scan 全局按游标增量遍历键
+func Scan(cursor int, match string, count int) (int, []string) {
+	return GetGlobalCache().Scan(cursor, match, count)
+}
+
+// KeysMatch 全局返回匹配glob模式的键
+func KeysMatch(pattern string) []string {
+	return GetGlobalCache().KeysMatch(pattern)
+}
+
 // Flush 全局清空所有数据
 func Flush() error {
 	return GetGlobalCache().Flush()
@@ -115,11 +323,31 @@ func Expire(key string, ttl time.Duration) bool {
 	return GetGlobalCache().Expire(key, ttl)
 }
 
+// ExpireMatching 全局为匹配glob模式的键设置新的TTL
+func ExpireMatching(pattern string, ttl time.Duration) int {
+	return GetGlobalCache().ExpireMatching(pattern, ttl)
+}
+
 // TTL 全局获取剩余生存时间
 func TTL(key string) (time.Duration, bool) {
 	return GetGlobalCache().TTL(key)
 }
 
+// PTTL 全局获取剩余生存时间（毫秒）
+func PTTL(key string) int64 {
+	return GetGlobalCache().PTTL(key)
+}
+
+// ExpireTime 全局获取过期时间的Unix时间戳（秒）
+func ExpireTime(key string) int64 {
+	return GetGlobalCache().ExpireTime(key)
+}
+
+// PExpireTime 全局获取过期时间的Unix时间戳（毫秒）
+func PExpireTime(key string) int64 {
+	return GetGlobalCache().PExpireTime(key)
+}
+
 // Stats 全局Get statistics
 func Stats() interface{} {
 	return GetGlobalCache().Stats()
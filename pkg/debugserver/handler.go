@@ -0,0 +1,216 @@
+// Package debugserver 提供用于本地调试的HTTP Handler，暴露缓存内部状态
+package debugserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// 默认分页大小
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// Config 调试Handler配置
+type Config struct {
+	AllowMutation bool // 是否开放修改类接口（/delete、/flush），默认只读
+	PageSize      int  // /keys 默认分页大小
+}
+
+// handler 调试Handler实现
+type handler struct {
+	engine interfaces.StorageEngine
+	config Config
+	mux    *http.ServeMux
+}
+
+// Handler 创建只读调试Handler
+func Handler(engine interfaces.StorageEngine) http.Handler {
+	return NewHandler(engine, nil)
+}
+
+// NewHandler 按配置创建调试Handler
+func NewHandler(engine interfaces.StorageEngine, cfg *Config) http.Handler {
+	h := &handler{
+		engine: engine,
+		mux:    http.NewServeMux(),
+	}
+	if cfg != nil {
+		h.config = *cfg
+	}
+	if h.config.PageSize <= 0 {
+		h.config.PageSize = DefaultPageSize
+	}
+
+	h.mux.HandleFunc("/keys", h.handleKeys)
+	h.mux.HandleFunc("/stats", h.handleStats)
+	h.mux.HandleFunc("/get", h.handleGet)
+	h.mux.HandleFunc("/ttl", h.handleTTL)
+	h.mux.HandleFunc("/health", h.handleHealth)
+
+	if h.config.AllowMutation {
+		h.mux.HandleFunc("/delete", h.handleDelete)
+		h.mux.HandleFunc("/flush", h.handleFlush)
+	}
+
+	return h
+}
+
+// ServeHTTP 实现http.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// handleKeys 分页返回排序后的键
+func (h *handler) handleKeys(w http.ResponseWriter, r *http.Request) {
+	page := queryInt(r, "page", 1)
+	size := queryInt(r, "size", h.config.PageSize)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > MaxPageSize {
+		size = h.config.PageSize
+	}
+
+	writeJSON(w, http.StatusOK, types.PageKeys(h.engine.Keys(), page, size))
+}
+
+// handleStats 返回引擎统计信息
+func (h *handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.engine.Stats())
+}
+
+// handleGet 返回指定键的值
+func (h *handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "key is required")
+		return
+	}
+
+	obj, exists := h.engine.Get(key)
+	if !exists {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"key":   key,
+		"type":  obj.Type(),
+		"value": extractValue(obj),
+	})
+}
+
+// handleTTL 返回指定键的剩余生存时间
+func (h *handler) handleTTL(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "key is required")
+		return
+	}
+
+	ttl, exists := h.engine.TTL(key)
+	if !exists {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	result := map[string]interface{}{
+		"key":    key,
+		"ttl_ms": ttl.Milliseconds(),
+	}
+	if ttl < 0 {
+		result["ttl_ms"] = -1
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleHealth 返回健康状态
+func (h *handler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ok",
+		"keys":   h.engine.Size(),
+		"time":   time.Now().Format(time.RFC3339),
+	})
+}
+
+// handleDelete 删除指定键（需开启AllowMutation）
+func (h *handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "key is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"key":     key,
+		"deleted": h.engine.Delete(key),
+	})
+}
+
+// handleFlush 清空缓存（需开启AllowMutation）
+func (h *handler) handleFlush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err := h.engine.Flush(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"flushed": true})
+}
+
+// extractValue 按数据类型提取可序列化的值
+func extractValue(obj interfaces.DataObject) interface{} {
+	switch obj.Type() {
+	case interfaces.DataTypeString:
+		value, _ := utils.ExtractStringValue(obj)
+		return value
+	case interfaces.DataTypeList:
+		values, _ := utils.ExtractListValue(obj)
+		return values
+	case interfaces.DataTypeHash:
+		fields, _ := utils.ExtractHashValue(obj)
+		return fields
+	case interfaces.DataTypeSet:
+		members, _ := utils.ExtractSetValue(obj)
+		return members
+	default:
+		return nil
+	}
+}
+
+// queryInt 读取整数查询参数
+func queryInt(r *http.Request, name string, defaultValue int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// writeJSON 写入JSON响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 写入JSON错误响应
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
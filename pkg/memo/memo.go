@@ -0,0 +1,386 @@
+// Package memo 函数记忆化装饰器，结果默认保存在内存中，也可写入共享的LocalCache
+package memo
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/errors"
+)
+
+// options 记忆化配置
+type options struct {
+	cache        *cache.LocalCache
+	ttl          time.Duration
+	prefix       string
+	stats        *Stats
+	limiter      *Limiter
+	onStoreError func(key string, err error)
+}
+
+// Limiter 限制同时执行的加载数，可在多个记忆化函数之间共享，用于在大量未命中时保护后端
+type Limiter struct {
+	slots    chan struct{}
+	failFast bool
+}
+
+// NewLimiter 创建最多允许limit个加载同时执行的限流器（limit小于1时按1处理）
+// failFast为true时超出上限的加载立即返回ErrLoadShed，否则等待空闲名额
+func NewLimiter(limit int, failFast bool) *Limiter {
+	if limit < 1 {
+		limit = 1
+	}
+	return &Limiter{slots: make(chan struct{}, limit), failFast: failFast}
+}
+
+// acquire 获取加载名额，wait为true时总是等待
+func (l *Limiter) acquire(wait bool) bool {
+	if wait || !l.failFast {
+		l.slots <- struct{}{}
+		return true
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release 归还加载名额
+func (l *Limiter) release() {
+	<-l.slots
+}
+
+// InFlight 返回正在执行的加载数
+func (l *Limiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Stats 加载合并统计，用于衡量single-flight对缓存击穿的保护效果
+type Stats struct {
+	requests  atomic.Int64 // 未命中缓存、进入加载路径的请求数
+	loads     atomic.Int64 // 实际执行fn的次数
+	coalesced atomic.Int64 // 未执行fn而共享其他请求结果的次数
+}
+
+// TotalLoadRequests 返回未命中缓存的请求数
+func (s *Stats) TotalLoadRequests() int64 {
+	return s.requests.Load()
+}
+
+// Loads 返回实际加载次数
+func (s *Stats) Loads() int64 {
+	return s.loads.Load()
+}
+
+// CoalescedLoads 返回被合并的请求数
+func (s *Stats) CoalescedLoads() int64 {
+	return s.coalesced.Load()
+}
+
+// CoalescingRatio 返回被合并请求占加载请求的比例
+func (s *Stats) CoalescingRatio() float64 {
+	requests := s.requests.Load()
+	if requests == 0 {
+		return 0
+	}
+	return float64(s.coalesced.Load()) / float64(requests)
+}
+
+// Snapshot 以map形式返回统计信息
+func (s *Stats) Snapshot() map[string]interface{} {
+	return map[string]interface{}{
+		"total_load_requests": s.TotalLoadRequests(),
+		"loads":               s.Loads(),
+		"coalesced_loads":     s.CoalescedLoads(),
+		"coalescing_ratio":    s.CoalescingRatio(),
+	}
+}
+
+// Option 记忆化选项
+type Option func(*options)
+
+// WithTTL 设置结果的缓存时间，0表示永不过期
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithCache 将结果以JSON写入指定的缓存实例（默认在内存中按参数保存结果原值）
+// 注意：结果经JSON往返后读取，只适用于能完整序列化的V；未导出字段会丢失，无法序列化的值（func、chan）不会被缓存，
+// 可用WithOnStoreError获知写入失败
+func WithCache(c *cache.LocalCache) Option {
+	return func(o *options) {
+		o.cache = c
+	}
+}
+
+// WithOnStoreError 设置使用WithCache时结果写入缓存失败的回调，写入失败时本次调用仍返回计算结果
+func WithOnStoreError(fn func(key string, err error)) Option {
+	return func(o *options) {
+		o.onStoreError = fn
+	}
+}
+
+// WithPrefix 设置缓存键前缀，使用WithCache且多个函数共享同一缓存时用于区分
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithStats 将加载合并统计记录到stats
+func WithStats(stats *Stats) Option {
+	return func(o *options) {
+		o.stats = stats
+	}
+}
+
+// WithLimiter 使用限流器限制同时执行的加载数（按不同参数计，相同参数的并发调用已合并为一次加载）
+func WithLimiter(l *Limiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
+// Memoize 返回fn的记忆化版本：结果按参数保存，相同参数的并发调用只执行一次fn；配置了限流器时总是等待加载名额
+// 默认在内存中按参数值保存结果原值；使用WithCache时以JSON写入共享缓存，见WithCache的说明
+// fn panic时panic在执行fn的调用中抛出，共享该次加载的其他调用重新加载
+func Memoize[K comparable, V any](fn func(K) V, opts ...Option) func(K) V {
+	memoized := memoize(func(arg K) (V, error) {
+		return fn(arg), nil
+	}, true, opts...)
+
+	return func(arg K) V {
+		for {
+			v, err := memoized(arg)
+			if err != errors.ErrLoaderPanicked {
+				return v
+			}
+		}
+	}
+}
+
+// MemoizeE 返回可失败函数的记忆化版本，fn返回错误时不缓存结果；
+// 快速失败的限流器已满时返回ErrLoadShed，共享的加载panic时等待者返回ErrLoaderPanicked
+func MemoizeE[K comparable, V any](fn func(K) (V, error), opts ...Option) func(K) (V, error) {
+	return memoize(fn, false, opts...)
+}
+
+// resultStore 记忆化结果的存储
+type resultStore[K comparable, V any] interface {
+	get(arg K) (V, bool)
+	set(arg K, value V)
+}
+
+// memoize 记忆化实现，waitForSlot为true时忽略限流器的快速失败策略
+func memoize[K comparable, V any](fn func(K) (V, error), waitForSlot bool, opts ...Option) func(K) (V, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var results resultStore[K, V] = &memoryStore[K, V]{ttl: o.ttl}
+	if o.cache != nil {
+		results = &cacheStore[K, V]{cache: o.cache, prefix: o.prefix, ttl: o.ttl, onStoreError: o.onStoreError}
+	}
+
+	var loads group[K, V]
+	return func(arg K) (V, error) {
+		if v, found := results.get(arg); found {
+			return v, nil
+		}
+
+		loaded := false
+		v, err, _ := loads.do(arg, func() (V, error) {
+			// 等待期间其他调用可能已写入结果
+			if v, found := results.get(arg); found {
+				return v, nil
+			}
+
+			if o.limiter != nil {
+				if !o.limiter.acquire(waitForSlot) {
+					var zero V
+					return zero, errors.ErrLoadShed
+				}
+				defer o.limiter.release()
+			}
+
+			loaded = true
+			result, err := fn(arg)
+			if err != nil {
+				return result, err
+			}
+			results.set(arg, result)
+			return result, nil
+		})
+
+		if o.stats != nil {
+			o.stats.requests.Add(1)
+			if loaded {
+				o.stats.loads.Add(1)
+			} else if err != errors.ErrLoadShed {
+				o.stats.coalesced.Add(1)
+			}
+		}
+
+		if err != nil {
+			var zero V
+			return zero, err
+		}
+		return v, nil
+	}
+}
+
+// flight 一次正在进行的加载
+type flight[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// group 合并同一参数的并发加载，按参数值而不是其字符串形式区分
+type group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flight[V]
+}
+
+// do 执行fn并返回结果；同一参数已有加载在进行时等待并共享其结果，shared表示结果是否来自其他调用
+// fn panic时panic在当前调用中继续抛出，等待者收到ErrLoaderPanicked
+func (g *group[K, V]) do(arg K, fn func() (V, error)) (value V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*flight[V])
+	}
+	if f, ok := g.calls[arg]; ok {
+		g.mu.Unlock()
+		<-f.done
+		return f.value, f.err, true
+	}
+
+	f := &flight[V]{done: make(chan struct{})}
+	g.calls[arg] = f
+	g.mu.Unlock()
+
+	panicked := true
+	defer func() {
+		if panicked {
+			f.err = errors.ErrLoaderPanicked
+		}
+		g.mu.Lock()
+		delete(g.calls, arg)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.value, f.err = fn()
+	panicked = false
+	return f.value, f.err, false
+}
+
+// minSweepEntries 内存存储的条目数达到该值后才开始清理过期条目
+const minSweepEntries = 64
+
+// memoEntry 内存中保存的结果
+type memoEntry[V any] struct {
+	value     V
+	expiresAt time.Time // 零值表示永不过期
+}
+
+// memoryStore 在内存中按参数值保存结果原值，不经过序列化
+// 配置了TTL时，条目数每增长一倍清理一次过期条目，不再调用的参数也会被回收
+type memoryStore[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[K]memoEntry[V]
+	sweepAt int // 条目数达到该值时清理过期条目
+}
+
+func (s *memoryStore[K, V]) get(arg K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[arg]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, arg)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (s *memoryStore[K, V]) set(arg K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries == nil {
+		s.entries = make(map[K]memoEntry[V])
+	}
+	entry := memoEntry[V]{value: value}
+	if s.ttl > 0 {
+		entry.expiresAt = time.Now().Add(s.ttl)
+	}
+	s.entries[arg] = entry
+
+	if s.ttl > 0 && len(s.entries) >= max(s.sweepAt, minSweepEntries) {
+		now := time.Now()
+		for key, entry := range s.entries {
+			if now.After(entry.expiresAt) {
+				delete(s.entries, key)
+			}
+		}
+		s.sweepAt = 2 * len(s.entries)
+	}
+}
+
+// cacheStore 以Store的JSON格式将结果写入共享的LocalCache，键为前缀加参数的类型和值
+type cacheStore[K comparable, V any] struct {
+	cache        *cache.LocalCache
+	prefix       string
+	ttl          time.Duration
+	onStoreError func(key string, err error)
+}
+
+// key 返回参数对应的缓存键，包含参数的动态类型，避免int(1)与int64(1)等值相同的参数共用条目
+func (s *cacheStore[K, V]) key(arg K) string {
+	return s.prefix + fmt.Sprintf("%T:%#v", arg, arg)
+}
+
+func (s *cacheStore[K, V]) get(arg K) (V, bool) {
+	v, found, err := cache.GetStruct[V](s.cache, s.key(arg))
+	return v, found && err == nil
+}
+
+func (s *cacheStore[K, V]) set(arg K, value V) {
+	key := s.key(arg)
+	if err := s.cache.Store(key, value, s.ttl); err != nil && s.onStoreError != nil {
+		s.onStoreError(key, err) // 写入失败时仍返回计算结果
+	}
+}
+
+// pair 双参数记忆化的组合键
+type pair[A, B comparable] struct {
+	A A
+	B B
+}
+
+// Memoize2 双参数版本，按参数组合缓存
+func Memoize2[A, B comparable, V any](fn func(A, B) V, opts ...Option) func(A, B) V {
+	memoized := Memoize(func(p pair[A, B]) V {
+		return fn(p.A, p.B)
+	}, opts...)
+
+	return func(a A, b B) V {
+		return memoized(pair[A, B]{A: a, B: b})
+	}
+}
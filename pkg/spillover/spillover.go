@@ -0,0 +1,306 @@
+// Package spillover 将淘汰的条目追加写入磁盘文件，作为内存缓存的二级存储：
+// 注册为EngineConfig.OnEvict回调后，淘汰即降级到磁盘，未命中时可通过Load取回
+package spillover
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+)
+
+// Spillover 基于追加写文件的溢出存储，记录格式与Export相同（长度前缀 + JSON记录）
+// 取回的键以删除记录（Type为空的记录）标记，重新打开时不会再被索引
+type Spillover struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	size    int64            // 文件当前长度，即下一条记录的偏移
+	offsets map[string]int64 // 每个键最新记录的偏移
+	stale   int              // 文件中已失效的记录数（被覆盖的记录和删除记录）
+	err     error            // 第一次写入失败的错误
+}
+
+// Open 打开或创建溢出文件，已有文件会被扫描以重建索引；
+// 失效记录不少于有效记录时重写文件，只保留未过期的有效记录
+func Open(path string) (*Spillover, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Spillover{path: path, file: file, offsets: make(map[string]int64)}
+	if err := s.scan(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("scan spillover file: %w", err)
+	}
+	if s.stale > 0 && s.stale >= len(s.offsets) {
+		if err := s.compact(); err != nil {
+			s.file.Close()
+			return nil, fmt.Errorf("compact spillover file: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// tombstone 返回标记键已被取回的删除记录
+func tombstone(key string) *storage.Record {
+	return &storage.Record{Key: key, Value: json.RawMessage("null")}
+}
+
+// isTombstone 判断记录是否为删除记录
+func isTombstone(record *storage.Record) bool {
+	return record.Type == ""
+}
+
+// scan 读取已有记录建立键到偏移的索引，末尾不完整的记录会被截断
+func (s *Spillover) scan() error {
+	r := &countingReader{r: bufio.NewReader(s.file)}
+	var offset int64
+
+	for {
+		record, err := storage.ReadRecord(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// 上次写入中断，丢弃不完整的尾部
+			if err := s.file.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := s.offsets[record.Key]; ok {
+			s.stale++
+		}
+		if isTombstone(record) {
+			delete(s.offsets, record.Key)
+			s.stale++
+		} else {
+			s.offsets[record.Key] = offset
+		}
+		offset = r.n
+	}
+
+	s.size = offset
+	return nil
+}
+
+// compact 将未过期的有效记录按原顺序写入临时文件并替换溢出文件
+func (s *Spillover) compact() error {
+	keys := make([]string, 0, len(s.offsets))
+	for key := range s.offsets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.offsets[keys[i]] < s.offsets[keys[j]]
+	})
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".compact-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // 重命名成功后为空操作
+
+	var counter countingWriter
+	bw := bufio.NewWriter(tmp)
+	w := io.MultiWriter(bw, &counter)
+	offsets := make(map[string]int64, len(keys))
+	now := time.Now().UnixNano()
+	for _, key := range keys {
+		record, err := s.readAt(s.offsets[key])
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		if record.ExpiresAt != 0 && record.ExpiresAt <= now {
+			continue
+		}
+		offsets[key] = int64(counter)
+		if err := storage.WriteRecord(w, record); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = file
+	s.size = int64(counter)
+	s.offsets = offsets
+	s.stale = 0
+	return nil
+}
+
+// readAt 读取偏移处的记录
+func (s *Spillover) readAt(offset int64) (*storage.Record, error) {
+	return storage.ReadRecord(io.NewSectionReader(s.file, offset, s.size-offset))
+}
+
+// countingReader 统计已读取的字节数
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// countingWriter 只统计写入字节数
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// OnEvict 追加写入被淘汰的条目，可直接用作EngineConfig.OnEvict
+// 回调无法返回错误，写入失败可通过Err查询
+func (s *Spillover) OnEvict(key string, obj interfaces.DataObject) {
+	if obj.IsExpired() {
+		return
+	}
+
+	record, err := storage.NewRecord(key, obj)
+	if err == nil {
+		err = s.append(record)
+	}
+	if err != nil {
+		s.mu.Lock()
+		if s.err == nil {
+			s.err = err
+		}
+		s.mu.Unlock()
+	}
+}
+
+// append 在文件末尾写入一条记录并更新索引
+func (s *Spillover) append(record *storage.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return os.ErrClosed
+	}
+	return s.appendLocked(record)
+}
+
+// appendLocked 同append，调用方持有锁；删除记录会把键从索引中移除
+func (s *Spillover) appendLocked(record *storage.Record) error {
+	var counter countingWriter
+	w := io.MultiWriter(io.NewOffsetWriter(s.file, s.size), &counter)
+	if err := storage.WriteRecord(w, record); err != nil {
+		return err
+	}
+
+	if _, ok := s.offsets[record.Key]; ok {
+		s.stale++
+	}
+	if isTombstone(record) {
+		delete(s.offsets, record.Key)
+		s.stale++
+	} else {
+		s.offsets[record.Key] = s.size
+	}
+	s.size += int64(counter)
+	return nil
+}
+
+// Load 读取键最近一次溢出的条目，并写入删除记录将其从溢出文件中移除（取回即提升回内存），
+// 键不存在或已过期时返回false
+func (s *Spillover) Load(key string) (interfaces.DataObject, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil, false, os.ErrClosed
+	}
+
+	offset, ok := s.offsets[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	record, err := s.readAt(offset)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.appendLocked(tombstone(key)); err != nil {
+		return nil, false, err
+	}
+
+	return record.Object()
+}
+
+// Restore 从溢出文件取回键并写回引擎，返回是否取回成功
+func (s *Spillover) Restore(engine interfaces.StorageEngine, key string) (bool, error) {
+	obj, ok, err := s.Load(key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := engine.Set(key, obj); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Len 返回可取回的键数
+func (s *Spillover) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.offsets)
+}
+
+// Err 返回第一次写入失败的错误
+func (s *Spillover) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close 关闭溢出文件，可重复调用
+func (s *Spillover) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,215 @@
+// Package workerpool 提供多个缓存共享的有界后台任务池，
+// 用固定数量的goroutine执行周期性任务（如过期清理），避免每个引擎各自启动goroutine
+package workerpool
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// idleWait 没有任务时调度器的等待时长
+const idleWait = time.Hour
+
+// Pool 有界后台任务池
+type Pool struct {
+	tasks chan func()
+	wake  chan struct{}
+	done  chan struct{}
+
+	mu   sync.Mutex
+	jobs map[*Job]struct{}
+
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// Job 周期性任务句柄
+type Job struct {
+	pool     *Pool
+	interval time.Duration
+	fn       func()
+	next     time.Time
+	running  atomic.Bool // 同一任务不会并发执行
+	stopped  atomic.Bool
+}
+
+// New 创建包含size个工作goroutine的任务池（另有一个调度goroutine），size小于1时按1处理
+func New(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &Pool{
+		tasks: make(chan func()),
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+		jobs:  make(map[*Job]struct{}),
+	}
+
+	p.wg.Add(size + 1)
+	for i := 0; i < size; i++ {
+		go p.worker()
+	}
+	go p.scheduler()
+
+	return p
+}
+
+// Submit 提交一次性任务，任务池已关闭时返回false
+func (p *Pool) Submit(fn func()) bool {
+	select {
+	case p.tasks <- fn:
+		return true
+	case <-p.done:
+		return false
+	}
+}
+
+// Every 注册每隔interval执行一次的任务，上一次执行未结束时跳过本轮
+func (p *Pool) Every(interval time.Duration, fn func()) *Job {
+	return p.EveryAfter(interval, interval, fn)
+}
+
+// EveryAfter 与Every相同，但首次执行在delay之后
+func (p *Pool) EveryAfter(delay, interval time.Duration, fn func()) *Job {
+	job := &Job{
+		pool:     p,
+		interval: interval,
+		fn:       fn,
+		next:     time.Now().Add(delay),
+	}
+
+	p.mu.Lock()
+	p.jobs[job] = struct{}{}
+	p.mu.Unlock()
+
+	p.notify()
+	return job
+}
+
+// SetInterval 修改任务的执行间隔，新间隔更短时提前下一次执行
+func (j *Job) SetInterval(interval time.Duration) {
+	j.pool.mu.Lock()
+	j.interval = interval
+	if next := time.Now().Add(interval); next.Before(j.next) {
+		j.next = next
+	}
+	j.pool.mu.Unlock()
+
+	j.pool.notify()
+}
+
+// Stop 取消周期性任务（正在执行的一轮不会被中断）
+func (j *Job) Stop() {
+	if j.stopped.Swap(true) {
+		return
+	}
+
+	j.pool.mu.Lock()
+	delete(j.pool.jobs, j)
+	j.pool.mu.Unlock()
+}
+
+// Close 停止任务池并等待所有goroutine退出
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+	p.wg.Wait()
+}
+
+// notify 唤醒调度器重新计算下一次执行时间
+func (p *Pool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// worker 执行提交的任务
+func (p *Pool) worker() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case fn := <-p.tasks:
+			fn()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// scheduler 按时间触发周期性任务
+func (p *Pool) scheduler() {
+	defer p.wg.Done()
+
+	timer := time.NewTimer(p.nextWait())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			p.runDue()
+		case <-p.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-p.done:
+			return
+		}
+		timer.Reset(p.nextWait())
+	}
+}
+
+// nextWait 计算距最近一个任务到期的时长
+func (p *Pool) nextWait() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	wait := idleWait
+	now := time.Now()
+	for job := range p.jobs {
+		if d := job.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// runDue 提交所有已到期的任务
+func (p *Pool) runDue() {
+	now := time.Now()
+	var due []*Job
+
+	p.mu.Lock()
+	for job := range p.jobs {
+		if job.next.After(now) {
+			continue
+		}
+		job.next = now.Add(job.interval)
+		if job.running.CompareAndSwap(false, true) {
+			due = append(due, job)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, job := range due {
+		ok := p.Submit(func() {
+			defer job.running.Store(false)
+			if !job.stopped.Load() {
+				job.fn()
+			}
+		})
+		if !ok {
+			return
+		}
+	}
+}
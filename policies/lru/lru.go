@@ -3,6 +3,7 @@ package lru
 import (
 	"container/list"
 	"sync"
+	"time"
 
 	"github.com/scache-io/scache/interfaces"
 )
@@ -21,6 +22,10 @@ func (n *noopPolicy) Clear()                      {}
 func (n *noopPolicy) Contains(key string) bool    { return false }
 func (n *noopPolicy) Keys() []string              { return nil }
 func (n *noopPolicy) UpdateCapacity(capacity int) {}
+func (n *noopPolicy) Name() string                { return "none" }
+func (n *noopPolicy) Stats() interfaces.PolicyStats {
+	return interfaces.PolicyStats{}
+}
 
 // lruPolicy LRUEviction policy的实现Struct
 type lruPolicy struct {
@@ -28,6 +33,8 @@ type lruPolicy struct {
 	cache    map[string]*list.Element // Map from key to list element，用于O(1)查找
 	list     *list.List               // Doubly linked list，头部为最近使用，尾部为最久未使用
 	mu       sync.RWMutex             // Read-write lock，保护并发访问
+	stats    interfaces.PolicyStats   // 运行统计，受mu保护
+	strict   bool                     // 严格模式：Access不添加未知键
 }
 
 // lruNode Node data stored in list
@@ -50,32 +57,58 @@ func NewLRUPolicy(capacity int) interfaces.EvictionPolicy {
 }
 
 // Access 访问指定键，将其标记为最近使用
-// 如果键不存在，则添加到缓存；如果超过容量，则淘汰最久未使用的条目
+// 如果键不存在，则添加到缓存（严格模式下忽略）；如果超过容量，则淘汰最久未使用的条目
 func (l *lruPolicy) Access(key string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.recordOp()
+
+	if _, exists := l.cache[key]; !exists && l.strict {
+		return
+	}
+	l.touch(key)
+}
+
+// Set 设置指定键的值，键不存在时总是添加
+func (l *lruPolicy) Set(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recordOp()
+
+	l.touch(key)
+}
 
+// touch 将键移到链表头部，不存在时添加并在超过容量时淘汰，必须在持有锁的情况下调用
+func (l *lruPolicy) touch(key string) {
 	if elem, exists := l.cache[key]; exists {
 		l.list.MoveToFront(elem) // 移动到链表头部，标记为最近使用
-	} else {
-		elem := l.list.PushFront(&lruNode{key: key}) // 添加新节点到头部
-		l.cache[key] = elem                          // 建立映射关系
+		return
+	}
 
-		if l.list.Len() > l.capacity {
-			l.evictInternal() // 超过容量时淘汰最久未使用的条目
-		}
+	l.cache[key] = l.list.PushFront(&lruNode{key: key}) // 添加新节点到头部并建立映射
+	if l.list.Len() > l.capacity {
+		l.evictInternal() // 超过容量时淘汰最久未使用的条目
 	}
 }
 
-// Set 设置指定键的值，等同于Access操作
-func (l *lruPolicy) Set(key string) {
-	l.Access(key)
+// Name 返回策略名称
+func (l *lruPolicy) Name() string {
+	return "lru"
+}
+
+// SetStrictAccess 设置严格模式，开启后Access不再为未知键创建条目
+func (l *lruPolicy) SetStrictAccess(strict bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.strict = strict
 }
 
 // Delete 从缓存中删除指定键的条目
 func (l *lruPolicy) Delete(key string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.recordOp()
 
 	if elem, exists := l.cache[key]; exists {
 		l.list.Remove(elem)  // 从链表中移除节点
@@ -87,6 +120,7 @@ func (l *lruPolicy) Delete(key string) {
 func (l *lruPolicy) Evict() string {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.recordOp()
 
 	return l.evictInternal()
 }
@@ -102,12 +136,27 @@ func (l *lruPolicy) evictInternal() string {
 		node := elem.Value.(*lruNode)
 		l.list.Remove(elem)       // 从链表中移除
 		delete(l.cache, node.key) // 从映射表中删除
-		return node.key           // 返回被淘汰的键
+		l.stats.Evictions++
+		return node.key // 返回被淘汰的键
 	}
 
 	return ""
 }
 
+// recordOp 记录一次操作，必须在持有锁的情况下调用
+func (l *lruPolicy) recordOp() {
+	l.stats.Operations++
+	l.stats.LastOpTime = time.Now()
+}
+
+// Stats 返回策略运行统计
+func (l *lruPolicy) Stats() interfaces.PolicyStats {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.stats
+}
+
 // Size 返回当前缓存中的条目数量
 func (l *lruPolicy) Size() int {
 	l.mu.RLock()
@@ -0,0 +1,232 @@
+package ttllru
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/policies/lru"
+)
+
+// 本包实现了先淘汰即将过期的键、再按LRU淘汰永久键的组合策略
+
+// ttlLRUPolicy TTL+LRU组合Eviction policy
+type ttlLRUPolicy struct {
+	lru      interfaces.EvictionPolicy // 所有键的LRU顺序
+	expiring expiryHeap                // 带过期时间的键，按过期时间升序
+	index    map[string]*expiryEntry   // 键到堆节点的映射
+	seq      uint64                    // 过期时间设置序号，过期时间相同时先设置的先淘汰
+	mu       sync.Mutex
+	strict   atomic.Bool // 严格模式：Access与SetExpiry忽略未知键
+
+	operations atomic.Int64
+	evictions  atomic.Int64
+	lastOp     atomic.Int64 // 最近一次操作时间（UnixNano）
+}
+
+// expiryEntry 堆节点
+type expiryEntry struct {
+	key       string
+	expiresAt time.Time
+	seq       uint64
+	index     int
+}
+
+// NewTTLLRUPolicy 创建TTL+LRU组合Eviction policy
+// capacity: Cache capacity，如果小于等于0则禁用Eviction policy
+func NewTTLLRUPolicy(capacity int) interfaces.EvictionPolicy {
+	inner := lru.NewLRUPolicy(capacity)
+	if capacity <= 0 {
+		return inner // 容量 <= 0 时禁用淘汰
+	}
+
+	return &ttlLRUPolicy{
+		lru:   inner,
+		index: make(map[string]*expiryEntry),
+	}
+}
+
+// Access 访问指定键，更新LRU顺序
+func (p *ttlLRUPolicy) Access(key string) {
+	p.recordOp()
+	p.lru.Access(key)
+}
+
+// Set 设置指定键，等同于Access操作
+func (p *ttlLRUPolicy) Set(key string) {
+	p.recordOp()
+	p.lru.Set(key)
+}
+
+// Name 返回策略名称
+func (p *ttlLRUPolicy) Name() string {
+	return "ttl-lru"
+}
+
+// SetStrictAccess 设置严格模式，开启后Access不再为未知键创建条目
+func (p *ttlLRUPolicy) SetStrictAccess(strict bool) {
+	p.strict.Store(strict)
+	if inner, ok := p.lru.(interfaces.StrictAccessPolicy); ok {
+		inner.SetStrictAccess(strict)
+	}
+}
+
+// SetExpiry 记录键的过期时间，零值表示永不过期
+// 严格模式下忽略LRU中不存在的键，避免堆中积累无主条目
+func (p *ttlLRUPolicy) SetExpiry(key string, expiresAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.strict.Load() && !p.lru.Contains(key) {
+		p.removeExpiry(key)
+		return
+	}
+
+	entry, exists := p.index[key]
+	switch {
+	case expiresAt.IsZero() && exists:
+		heap.Remove(&p.expiring, entry.index)
+		delete(p.index, key)
+	case expiresAt.IsZero():
+	case exists:
+		p.seq++
+		entry.expiresAt = expiresAt
+		entry.seq = p.seq
+		heap.Fix(&p.expiring, entry.index)
+	default:
+		p.seq++
+		entry = &expiryEntry{key: key, expiresAt: expiresAt, seq: p.seq}
+		heap.Push(&p.expiring, entry)
+		p.index[key] = entry
+	}
+}
+
+// Delete 删除指定键
+func (p *ttlLRUPolicy) Delete(key string) {
+	p.recordOp()
+
+	p.mu.Lock()
+	p.removeExpiry(key)
+	p.mu.Unlock()
+
+	p.lru.Delete(key)
+}
+
+// Evict 优先淘汰最接近过期的键，没有带TTL的键时淘汰最久未使用的键
+func (p *ttlLRUPolicy) Evict() string {
+	p.recordOp()
+
+	p.mu.Lock()
+	for p.expiring.Len() > 0 {
+		entry := heap.Pop(&p.expiring).(*expiryEntry)
+		delete(p.index, entry.key)
+
+		// 跳过已被LRU因容量调整移除的键
+		if !p.lru.Contains(entry.key) {
+			continue
+		}
+		p.mu.Unlock()
+
+		p.lru.Delete(entry.key)
+		p.evictions.Add(1)
+		return entry.key
+	}
+	p.mu.Unlock()
+
+	key := p.lru.Evict()
+	if key != "" {
+		p.evictions.Add(1)
+	}
+	return key
+}
+
+// recordOp 记录一次操作
+func (p *ttlLRUPolicy) recordOp() {
+	p.operations.Add(1)
+	p.lastOp.Store(time.Now().UnixNano())
+}
+
+// Stats 返回策略运行统计
+func (p *ttlLRUPolicy) Stats() interfaces.PolicyStats {
+	stats := interfaces.PolicyStats{
+		Operations: p.operations.Load(),
+		Evictions:  p.evictions.Load(),
+	}
+	if lastOp := p.lastOp.Load(); lastOp > 0 {
+		stats.LastOpTime = time.Unix(0, lastOp)
+	}
+	return stats
+}
+
+// removeExpiry 从堆中移除键，必须在持有锁的情况下调用
+func (p *ttlLRUPolicy) removeExpiry(key string) {
+	if entry, exists := p.index[key]; exists {
+		heap.Remove(&p.expiring, entry.index)
+		delete(p.index, key)
+	}
+}
+
+// Size 返回当前条目数量
+func (p *ttlLRUPolicy) Size() int {
+	return p.lru.Size()
+}
+
+// Clear 清空所有条目
+func (p *ttlLRUPolicy) Clear() {
+	p.mu.Lock()
+	p.expiring = nil
+	p.index = make(map[string]*expiryEntry)
+	p.mu.Unlock()
+
+	p.lru.Clear()
+}
+
+// Contains 检查指定键是否存在
+func (p *ttlLRUPolicy) Contains(key string) bool {
+	return p.lru.Contains(key)
+}
+
+// Keys 返回所有键，按最近使用顺序排列
+func (p *ttlLRUPolicy) Keys() []string {
+	return p.lru.Keys()
+}
+
+// UpdateCapacity 更新Cache capacity
+func (p *ttlLRUPolicy) UpdateCapacity(newCapacity int) {
+	p.lru.UpdateCapacity(newCapacity)
+}
+
+// expiryHeap 按过期时间排序的最小堆，过期时间相同时按设置顺序
+type expiryHeap []*expiryEntry
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool {
+	if h[i].expiresAt.Equal(h[j].expiresAt) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].expiresAt.Before(h[j].expiresAt)
+}
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap) Push(x interface{}) {
+	entry := x.(*expiryEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
@@ -1,5 +1,5 @@
 // Package scache provides a high-performance, thread-safe in-memory cache for Go.
-// It supports multiple data types (String, List, Hash, Struct), TTL expiration,
+// It supports multiple data types (String, List, Hash, Set, Struct), TTL expiration,
 // and LRU eviction policy.
 //
 // Quick Start:
@@ -13,10 +13,14 @@
 package scache
 
 import (
+	"time"
+
+	"github.com/scache-io/scache/cache"
 	"github.com/scache-io/scache/config"
 	"github.com/scache-io/scache/errors"
 	"github.com/scache-io/scache/interfaces"
 	"github.com/scache-io/scache/pkg/api"
+	"github.com/scache-io/scache/storage"
 	"github.com/scache-io/scache/types"
 )
 
@@ -40,22 +44,41 @@ type (
 	// HashObject Hash object interface
 	HashObject = interfaces.HashObject
 
+	// SetObject Set object interface
+	SetObject = interfaces.SetObject
+
 	// StructObject Struct object interface
 	StructObject = interfaces.StructObject
 
 	// DataType Data type
 	DataType = interfaces.DataType
+
+	// ListEnd List end (head or tail) used by LMove
+	ListEnd = interfaces.ListEnd
+
+	// SetOptions Conditional set options (NX/XX/KEEPTTL)
+	SetOptions = interfaces.SetOptions
+
+	// BatchError Batch write failure listing the keys that were not written
+	BatchError = errors.BatchError
 )
 
 // Public errors
 var (
-	ErrKeyEmpty        = errors.ErrKeyEmpty
-	ErrInvalidArgument = errors.ErrInvalidArgument
-	ErrTypeMismatch    = errors.ErrTypeMismatch
-	ErrKeyNotFound     = errors.ErrKeyNotFound
-	ErrFieldNotFound   = errors.ErrFieldNotFound
-	ErrIndexOutOfRange = errors.ErrIndexOutOfRange
-	ErrListEmpty       = errors.ErrListEmpty
+	ErrKeyEmpty          = errors.ErrKeyEmpty
+	ErrInvalidArgument   = errors.ErrInvalidArgument
+	ErrTypeMismatch      = errors.ErrTypeMismatch
+	ErrKeyNotFound       = errors.ErrKeyNotFound
+	ErrFieldNotFound     = errors.ErrFieldNotFound
+	ErrIndexOutOfRange   = errors.ErrIndexOutOfRange
+	ErrListEmpty         = errors.ErrListEmpty
+	ErrGlobalInitialized = errors.ErrGlobalInitialized
+	ErrTTLBelowMinimum   = errors.ErrTTLBelowMinimum
+	ErrCacheClosed       = errors.ErrCacheClosed
+	ErrLoadShed          = errors.ErrLoadShed
+	ErrBatchTooLarge     = errors.ErrBatchTooLarge
+	ErrLoaderTimeout     = errors.ErrLoaderTimeout
+	ErrLoaderPanicked    = errors.ErrLoaderPanicked
 )
 
 // Public constants
@@ -63,41 +86,105 @@ const (
 	DataTypeString = interfaces.DataTypeString
 	DataTypeList   = interfaces.DataTypeList
 	DataTypeHash   = interfaces.DataTypeHash
+	DataTypeSet    = interfaces.DataTypeSet
 	DataTypeStruct = interfaces.DataTypeStruct
+	ListLeft       = interfaces.ListLeft
+	ListRight      = interfaces.ListRight
 )
 
 // Local cache API
 var (
-	New             = api.New
-	GetGlobalCache  = api.GetGlobalCache
-	InitGlobalCache = api.InitGlobalCache
-	SetString       = api.SetString
-	GetString       = api.GetString
-	SetList         = api.SetList
-	GetList         = api.GetList
-	SetHash         = api.SetHash
-	GetHash         = api.GetHash
-	Store           = api.Store
-	Load            = api.Load
-	Delete          = api.Delete
-	Exists          = api.Exists
-	Keys            = api.Keys
-	Flush           = api.Flush
-	Size            = api.Size
-	Expire          = api.Expire
-	TTL             = api.TTL
-	Stats           = api.Stats
+	New                = api.New
+	GetGlobalCache     = api.GetGlobalCache
+	InitGlobalCache    = api.InitGlobalCache
+	ConfigureGlobal    = api.ConfigureGlobal
+	SetString          = api.SetString
+	GetString          = api.GetString
+	GetStringE         = api.GetStringE
+	SetList            = api.SetList
+	GetList            = api.GetList
+	LPush              = api.LPush
+	RPush              = api.RPush
+	LPop               = api.LPop
+	RPop               = api.RPop
+	LMove              = api.LMove
+	LLen               = api.LLen
+	LRange             = api.LRange
+	SetHash            = api.SetHash
+	GetHash            = api.GetHash
+	HSet               = api.HSet
+	HDel               = api.HDel
+	HDelMany           = api.HDelMany
+	HGetAll            = api.HGetAll
+	HKeys              = api.HKeys
+	HVals              = api.HVals
+	HLen               = api.HLen
+	HExists            = api.HExists
+	SAdd               = api.SAdd
+	SRem               = api.SRem
+	SMembers           = api.SMembers
+	SIsMember          = api.SIsMember
+	SCard              = api.SCard
+	Store              = api.Store
+	Load               = api.Load
+	GetInt             = api.GetInt
+	Delete             = api.Delete
+	DeleteMany         = api.DeleteMany
+	Observe            = api.Observe
+	SetNX              = api.SetNX
+	MSet               = api.MSet
+	MGet               = api.MGet
+	Incr               = api.Incr
+	Decr               = api.Decr
+	IncrBy             = api.IncrBy
+	GetAndDelete       = api.GetAndDelete
+	GetStringAndDelete = api.GetStringAndDelete
+	Exists             = api.Exists
+	Keys               = api.Keys
+	KeysByType         = api.KeysByType
+	KeysMatch          = api.KeysMatch
+	Scan               = api.Scan
+	Flush              = api.Flush
+	Size               = api.Size
+	Expire             = api.Expire
+	ExpireMatching     = api.ExpireMatching
+	TTL                = api.TTL
+	PTTL               = api.PTTL
+	ExpireTime         = api.ExpireTime
+	PExpireTime        = api.PExpireTime
+	Stats              = api.Stats
 )
 
+// GetStruct 全局按类型获取结构体值（泛型函数无法以变量形式导出）
+func GetStruct[T any](key string) (T, bool, error) {
+	return api.GetStruct[T](key)
+}
+
+// UpdateStruct 在键级锁内原子地读取、修改并存储类型化的struct值
+func UpdateStruct[T any](c *LocalCache, key string, ttl time.Duration, fn func(cur *T) (T, error)) error {
+	return cache.UpdateStruct[T](c, key, ttl, fn)
+}
+
+// GetOrStore 按类型读取键，未命中时合并并发加载并写入
+func GetOrStore[V any](c *LocalCache, key string, ttl time.Duration, loader func() (V, error)) (V, error) {
+	return cache.GetOrStore[V](c, key, ttl, loader)
+}
+
 // Config helpers
 var (
 	DefaultEngineConfig = config.DefaultEngineConfig
 )
 
+// Snapshot helpers
+var (
+	RegisterSnapshotMigration = storage.RegisterSnapshotMigration
+)
+
 // Type constructors
 var (
 	NewStringObject = types.NewStringObject
 	NewListObject   = types.NewListObject
 	NewHashObject   = types.NewHashObject
+	NewSetObject    = types.NewSetObject
 	NewStructObject = types.NewStructObject
 )
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scache-io/scache/types"
+)
+
+// accessEntry 访问日志条目
+type accessEntry struct {
+	key string
+	at  int64 // UnixNano
+}
+
+// accessLog 采样访问日志，固定大小的环形缓冲区保存最近的访问记录
+type accessLog struct {
+	mu      sync.Mutex
+	entries []accessEntry
+	next    int
+	count   atomic.Uint64 // 访问计数，用于采样
+	every   uint64
+}
+
+// newAccessLog 创建访问日志，size为0时返回nil表示禁用
+func newAccessLog(size, every int) *accessLog {
+	if size <= 0 {
+		return nil
+	}
+	if every < 1 {
+		every = 1
+	}
+	return &accessLog{
+		entries: make([]accessEntry, size),
+		every:   uint64(every),
+	}
+}
+
+// record 按采样间隔记录一次访问
+func (l *accessLog) record(key string) {
+	if l.count.Add(1)%l.every != 0 {
+		return
+	}
+
+	now := time.Now().UnixNano()
+	l.mu.Lock()
+	l.entries[l.next] = accessEntry{key: key, at: now}
+	l.next = (l.next + 1) % len(l.entries)
+	l.mu.Unlock()
+}
+
+// hotKeys 统计窗口内访问次数最多的top个键，次数相同时按键排序
+func (l *accessLog) hotKeys(window time.Duration, top int) []types.KeyInfo {
+	since := time.Now().Add(-window).UnixNano()
+	counts := make(map[string]*types.KeyInfo)
+
+	l.mu.Lock()
+	for _, entry := range l.entries {
+		if entry.key == "" || entry.at < since {
+			continue
+		}
+		info, ok := counts[entry.key]
+		if !ok {
+			info = &types.KeyInfo{Key: entry.key}
+			counts[entry.key] = info
+		}
+		info.Accesses++
+		if at := time.Unix(0, entry.at); at.After(info.LastAccess) {
+			info.LastAccess = at
+		}
+	}
+	l.mu.Unlock()
+
+	result := make([]types.KeyInfo, 0, len(counts))
+	for _, info := range counts {
+		result = append(result, *info)
+	}
+	return rankKeyInfos(result, top)
+}
+
+// rankKeyInfos 按访问次数降序（次数相同时按键）排序并保留前top个
+func rankKeyInfos(result []types.KeyInfo, top int) []types.KeyInfo {
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Accesses != result[j].Accesses {
+			return result[i].Accesses > result[j].Accesses
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	if top > 0 && len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
+
+// HotKeys 返回最近window内采样访问次数最多的top个键（top<=0表示全部），未启用访问日志时返回nil
+func (e *StorageEngine) HotKeys(window time.Duration, top int) []types.KeyInfo {
+	if e.accessLog == nil || e.closed.Load() {
+		return nil
+	}
+	return e.accessLog.hotKeys(window, top)
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+)
+
+// SetNX 仅在键不存在或已过期时写入，返回是否写入；检查与写入在同一次加锁内完成
+func (e *StorageEngine) SetNX(key string, obj interfaces.DataObject) (bool, error) {
+	return e.SetWithOptions(key, obj, interfaces.SetOptions{NX: true})
+}
+
+// SetWithOptions 按条件写入对象，返回是否写入；条件不满足时保留原值并返回false
+// NX与XX互斥，同时指定时返回ErrInvalidArgument；KeepTTL在键存在时沿用原对象的过期时间
+func (e *StorageEngine) SetWithOptions(key string, obj interfaces.DataObject, opts interfaces.SetOptions) (bool, error) {
+	if e.closed.Load() {
+		return false, errors.ErrCacheClosed
+	}
+	if opts.NX && opts.XX {
+		return false, errors.ErrInvalidArgument
+	}
+	key, err := e.prepareSet(key, obj)
+	if err != nil {
+		return false, err
+	}
+	if err := e.checkMemory(); err != nil {
+		return false, err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	existing, exists := e.data[key]
+	live := exists && !e.isExpired(existing)
+	if (opts.NX && live) || (opts.XX && !live) {
+		return false, nil
+	}
+	if opts.KeepTTL && live {
+		if setter, ok := obj.(expirySetter); ok {
+			setter.SetExpiresAtFrom(existing.ExpiresAt(), e.now())
+		}
+	}
+
+	if err := e.setLocked(key, obj, &notices); err != nil {
+		return false, err
+	}
+	return true, nil
+}
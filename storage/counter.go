@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"strconv"
+
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// IncrBy 将十进制整数字符串值加上delta并返回新值，读取与写回在同一次加锁内完成，保留原有的过期时间
+// 键不存在或已过期时从0开始并创建永不过期的键；值不是整数时返回ErrTypeMismatch，结果溢出时返回ErrInvalidArgument
+func (e *StorageEngine) IncrBy(key string, delta int64) (int64, error) {
+	if e.closed.Load() {
+		return 0, errors.ErrCacheClosed
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return 0, err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		if err := e.setLocked(key, types.NewStringObject(strconv.FormatInt(delta, 10), 0), &notices); err != nil {
+			return 0, err
+		}
+		return delta, nil
+	}
+
+	str, ok := obj.(*types.StringObject)
+	if !ok {
+		return 0, errors.ErrTypeMismatch
+	}
+	next, err := utils.IncrementInt(str.Value(), delta)
+	if err != nil {
+		return 0, err
+	}
+
+	before := str.Size()
+	str.Set(strconv.FormatInt(next, 10))
+	e.stats.updateMemoryUsage(int64(str.Size() - before))
+	e.notifyWatchers(key, str)
+	return next, nil
+}
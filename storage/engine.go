@@ -2,13 +2,22 @@ package storage
 
 import (
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"path"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/constants"
+	"github.com/scache-io/scache/errors"
 	"github.com/scache-io/scache/interfaces"
 	"github.com/scache-io/scache/internal"
+	"github.com/scache-io/scache/pkg/workerpool"
 	"github.com/scache-io/scache/policies/lru"
 	"github.com/scache-io/scache/types"
 	"github.com/scache-io/scache/utils"
@@ -23,29 +32,98 @@ type StorageEngine struct {
 	stats     *EngineStats
 	stopChan  chan struct{}
 	bgCleanup chan struct{}
+	cleanup   *workerpool.Job        // 在共享任务池中注册的清理任务
+	indexes   map[string]*fieldIndex // Hash字段二级索引（按字段名）
+	reserved  map[string]struct{}    // 已预留但尚未写入的键，占用容量且不会被淘汰
+	peakSize  int                    // 上次压缩以来的峰值键数
+	full      bool                   // 是否已达到MaxSize（用于OnFull回调去重）
+	softLimit int                    // 软限制键数，0表示禁用
+	softRearm int                    // 键数低于该值时重新启用软限制回调
+	softHit   bool                   // 是否已越过软限制（用于OnSoftLimit回调去重）
+	accessLog *accessLog             // 采样访问日志，nil表示禁用
+	interval  atomic.Int64           // 当前后台清理间隔（纳秒），自适应模式下随回收情况调整
+	closed    atomic.Bool            // Close后所有操作返回ErrCacheClosed或零值
+	startedAt time.Time              // 引擎创建时间，用于Stats中的uptime
+	watchers  map[string]watcherSet  // 按键分组的变化监听者
+	markMu    sync.Mutex             // 保护marked，读路径在读锁下登记
+	marked    []string               // 两阶段删除模式下已标记待清理的键
+	scan      scanIndex              // 按写入顺序记录的键，为Scan提供稳定游标
 }
 
-// EngineStats 引擎统计
+// EngineStats 引擎统计（计数器均为原子操作，热路径无需加锁）
 type EngineStats struct {
-	mu            sync.RWMutex
-	hits          int64
-	misses        int64
-	sets          int64
-	deletes       int64
-	evictions     int64
-	expirations   int64
-	memoryUsage   int64 // 字节
-	gcCycles      int64 // GC cycles count
-	poolHits      int64 // Object pool hits
-	poolAllocs    int64 // Object pool allocations (new objects created)
-	lastGCTime    time.Time
-}
-
-// NewStorageEngine 创建新的Storage engine
+	hits        atomic.Int64
+	misses      atomic.Int64
+	sets        atomic.Int64
+	deletes     atomic.Int64
+	evictions   atomic.Int64
+	expirations atomic.Int64
+	rejections  atomic.Int64 // AdmissionFilter拒绝的写入
+	memoryUsage atomic.Int64 // 字节
+	gcCycles    atomic.Int64 // GC cycles count
+	poolHits    atomic.Int64 // Object pool hits（删除的对象可能仍被读者持有，引擎不再回收对象，保留以兼容Stats输出）
+	poolAllocs  atomic.Int64 // Object pool allocations (new objects created)
+	lastGCTime  atomic.Int64 // 最近一次GC统计更新时间（UnixNano）
+
+	// 滑动窗口统计：两个桶交替使用，命中率取两个桶之和
+	window      time.Duration
+	clock       func() time.Time // 窗口轮换使用的时钟，与引擎的Clock一致，nil表示time.Now
+	buckets     [2]windowBucket
+	current     atomic.Int32
+	windowStart atomic.Int64 // 当前桶开始时间（UnixNano）
+}
+
+// windowBucket 窗口统计桶
+type windowBucket struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// newEngineStats 创建引擎统计，window大于0时启用窗口命中率，窗口按clock轮换（nil表示time.Now）
+func newEngineStats(window time.Duration, clock func() time.Time) *EngineStats {
+	s := &EngineStats{window: window, clock: clock}
+	s.windowStart.Store(s.now())
+	return s
+}
+
+// now 返回窗口统计使用的当前时间（UnixNano）
+func (s *EngineStats) now() int64 {
+	if s.clock != nil {
+		return s.clock().UnixNano()
+	}
+	return time.Now().UnixNano()
+}
+
+// NewStorageEngine 创建新的Storage engine，配置无效时panic（可先调用EngineConfig.Validate检查）
 func NewStorageEngine(engineConfig *config.EngineConfig) interfaces.StorageEngine {
 	if engineConfig == nil {
 		engineConfig = config.DefaultEngineConfig()
 	}
+	if err := engineConfig.Validate(); err != nil {
+		panic("scache: " + err.Error())
+	}
+	if engineConfig.Shards > 1 {
+		return newShardedEngine(engineConfig)
+	}
+	return newStorageEngine(engineConfig)
+}
+
+// newStorageEngine 按已校验的配置创建单锁引擎
+func newStorageEngine(engineConfig *config.EngineConfig) *StorageEngine {
+	newPolicy := lru.NewLRUPolicy
+	if engineConfig.PolicyFactory != nil {
+		newPolicy = engineConfig.PolicyFactory
+	}
+
+	// 只配置了MaxMemoryBytes时也需要淘汰策略记录访问顺序，按不限键数创建
+	capacity := engineConfig.MaxSize
+	if capacity <= 0 && engineConfig.MaxMemoryBytes > 0 {
+		capacity = math.MaxInt
+	}
+	policy := newPolicy(capacity)
+	if strict, ok := policy.(interfaces.StrictAccessPolicy); ok && engineConfig.StrictPolicyAccess {
+		strict.SetStrictAccess(true)
+	}
 
 	// Pre-allocate map capacity based on MaxSize to reduce GC pressure
 	initialCapacity := 64
@@ -55,16 +133,38 @@ func NewStorageEngine(engineConfig *config.EngineConfig) interfaces.StorageEngin
 
 	engine := &StorageEngine{
 		data:      make(map[string]interfaces.DataObject, initialCapacity),
-		policy:    lru.NewLRUPolicy(engineConfig.MaxSize),
+		policy:    policy,
 		config:    engineConfig,
-		stats:     &EngineStats{},
+		stats:     newEngineStats(engineConfig.StatsWindow, engineConfig.Clock),
 		stopChan:  make(chan struct{}),
 		bgCleanup: make(chan struct{}),
+		accessLog: newAccessLog(engineConfig.AccessLogSize, engineConfig.AccessLogSampleEvery),
+		startedAt: time.Now(),
+	}
+
+	if engineConfig.SoftLimitRatio > 0 && engineConfig.MaxSize > 0 {
+		engine.softLimit = int(math.Ceil(engineConfig.SoftLimitRatio * float64(engineConfig.MaxSize)))
+		engine.softRearm = engine.softLimit - max(1, int(constants.SoftLimitHysteresis*float64(engineConfig.MaxSize)))
 	}
 
-	// 启动后台清理
+	// 启动后台清理，配置了共享任务池时不单独启动goroutine
+	// 间隔为0时不启动任何清理任务，过期键只在访问时惰性删除
 	if engineConfig.BackgroundCleanupInterval > 0 {
-		engine.startBackgroundCleanup()
+		interval := engineConfig.BackgroundCleanupInterval
+		if engineConfig.AdaptiveCleanup {
+			minInterval, maxInterval := engineConfig.CleanupIntervalBounds()
+			interval = min(max(interval, minInterval), maxInterval)
+		}
+		engine.interval.Store(int64(interval))
+
+		if engineConfig.WorkerPool != nil {
+			// 清理任务可能在赋值前开始执行，持锁赋值以便cleanupExpired安全读取
+			engine.mu.Lock()
+			engine.cleanup = engineConfig.WorkerPool.EveryAfter(interval+engine.cleanupJitter(), interval, engine.cleanupExpired)
+			engine.mu.Unlock()
+		} else {
+			engine.startBackgroundCleanup()
+		}
 	}
 
 	return engine
@@ -72,58 +172,309 @@ func NewStorageEngine(engineConfig *config.EngineConfig) interfaces.StorageEngin
 
 // Set 存储对象
 func (e *StorageEngine) Set(key string, obj interfaces.DataObject) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+	key, err := e.prepareSet(key, obj)
+	if err != nil {
+		return err
+	}
+	if err := e.checkMemory(); err != nil {
+		return err
+	}
+
+	// OnFull/OnSoftLimit/OnEvict回调在释放锁之后执行，避免回调中访问引擎导致死锁
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.setLocked(key, obj, &notices)
+}
+
+// MSet 在一次加锁内写入多个对象，键的校验在加锁前完成，任一键无效时不写入任何键
+// 写入过程中出错（如严格模式下容量已满）时返回错误，已写入的键保留
+// 键数超过MaxBatchSize时按BatchOverflow整批拒绝或分块写入，返回的*errors.BatchError列出未写入的键
+func (e *StorageEngine) MSet(objs map[string]interfaces.DataObject) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+
+	limit := e.config.MaxBatchSize
+	oversized := limit > 0 && len(objs) > limit
+	if oversized && e.config.BatchOverflow == config.BatchReject {
+		rejected := make([]string, 0, len(objs))
+		for key := range objs {
+			rejected = append(rejected, key)
+		}
+		slices.Sort(rejected)
+		return &errors.BatchError{Rejected: rejected, Err: errors.ErrBatchTooLarge}
+	}
+
+	normalized := make(map[string]interfaces.DataObject, len(objs))
+	for key, obj := range objs {
+		key, err := e.prepareSet(key, obj)
+		if err != nil {
+			return err
+		}
+		normalized[key] = obj
+	}
+	if err := e.checkMemory(); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(normalized))
+	for key := range normalized {
+		keys = append(keys, key)
+	}
+	if oversized || e.config.DeterministicEviction {
+		slices.Sort(keys) // 固定写入顺序，淘汰顺序不依赖map遍历顺序
+	}
+	if !oversized {
+		_, err := e.msetChunk(keys, normalized)
+		return err
+	}
+
+	// 分块写入：每块单独加锁，出错时停止，失败的键及之后的键均未写入
+	for start := 0; start < len(keys); start += limit {
+		end := min(start+limit, len(keys))
+		if written, err := e.msetChunk(keys[start:end], normalized); err != nil {
+			return &errors.BatchError{Rejected: keys[start+written:], Err: err}
+		}
+	}
+	return nil
+}
+
+// msetChunk 在一次加锁内按顺序写入keys对应的对象，返回出错前写入的键数
+func (e *StorageEngine) msetChunk(keys []string, objs map[string]interfaces.DataObject) (int, error) {
+	notices := make([]setNotices, len(keys))
+	defer func() {
+		for i := range notices {
+			e.fireNotices(&notices[i])
+		}
+	}()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, key := range keys {
+		if err := e.setLocked(key, objs[key], &notices[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
+
+// prepareSet 规范化并校验键，按MinTTL调整对象的过期时间，返回规范化后的键
+func (e *StorageEngine) prepareSet(key string, obj interfaces.DataObject) (string, error) {
+	key = e.normalizeKey(key)
+
 	// 验证Parameter
 	if err := utils.ValidateCacheKey(key); err != nil {
-		return err
+		return "", err
 	}
+	e.rebaseExpiry(obj)
+	if err := e.applyMinTTL(obj); err != nil {
+		return "", err
+	}
+	return key, nil
+}
 
-	// 检查内存可用性（仅在禁用自动清理时进行严格检查）
+// rebaseExpiry 对象创建时按系统时间计算过期时间，配置了Clock时改为从注入的时钟开始计时
+func (e *StorageEngine) rebaseExpiry(obj interfaces.DataObject) {
+	if e.config.Clock == nil {
+		return
+	}
+	if s, ok := obj.(slider); ok {
+		s.SlideAt(e.now())
+	}
+}
+
+// checkMemory 检查内存可用性（仅在禁用自动清理时进行严格检查）
+func (e *StorageEngine) checkMemory() error {
 	if e.config.BackgroundCleanupInterval == 0 {
 		if err := internal.CheckMemoryAvailability(e.config.MemoryThreshold); err != nil {
 			return fmt.Errorf("memory limit exceeded: %w", err)
 		}
 	}
+	return nil
+}
 
-	e.mu.Lock()
-	defer e.mu.Unlock()
+// setNotices 写入过程中产生、需要在释放锁之后执行的回调参数
+type setNotices struct {
+	fullSize   int
+	softSize   int
+	evictedKey string
+	evictedObj interfaces.DataObject
+	memEvicted []keyedObject // 估算内存超过上限时淘汰的键
+}
+
+// fireNotices 执行写入产生的OnFull/OnSoftLimit/OnEvict回调，必须在释放锁之后调用
+func (e *StorageEngine) fireNotices(n *setNotices) {
+	if n.fullSize > 0 {
+		e.config.OnFull(n.fullSize, e.config.MaxSize)
+	}
+	if n.softSize > 0 {
+		e.config.OnSoftLimit(n.softSize, e.config.MaxSize)
+	}
+	e.notifyEvict(n.evictedKey, n.evictedObj)
+	for _, evicted := range n.memEvicted {
+		e.notifyEvict(evicted.key, evicted.obj)
+	}
+}
 
+// setLocked 写入规范化后的键，处理容量淘汰、内存统计和软限制，必须在持有写锁的情况下调用
+// 需要回调的参数记录在n中，由调用方释放锁之后通过fireNotices执行
+func (e *StorageEngine) setLocked(key string, obj interfaces.DataObject, n *setNotices) error {
 	// 检查是否需要淘汰（仅在配置了MaxSize时进行淘汰）
-	if e.config.MaxSize > 0 && len(e.data) >= e.config.MaxSize && e.data[key] == nil {
+	// 预留的键占用容量，写入预留键时消耗其预留而不触发淘汰
+	_, reserved := e.reserved[key]
+
+	// 准入过滤只作用于新键，已缓存或已预留的键总是写入，避免保留过期的旧值
+	if e.config.AdmissionFilter != nil && e.data[key] == nil && !reserved && !e.config.AdmissionFilter(key) {
+		e.stats.recordRejection()
+		return nil
+	}
+
+	if e.config.MaxSize > 0 && len(e.data)+len(e.reserved) >= e.config.MaxSize && e.data[key] == nil && !reserved {
+		// 首次达到容量时触发OnFull，降到容量以下后再次填满会重新触发
+		if !e.full {
+			e.full = true
+			if e.config.OnFull != nil {
+				n.fullSize = len(e.data)
+			}
+		}
+
 		// 如果没有自动清理，则拒绝新数据
 		if e.config.BackgroundCleanupInterval == 0 {
 			return fmt.Errorf("storage capacity exceeded: max size %d reached", e.config.MaxSize)
 		}
-		e.evictOne()
+		n.evictedKey, n.evictedObj = e.evictOne()
+	}
+
+	// 按对象大小更新内存统计，覆盖写入时扣除旧对象的大小
+	delta := int64(obj.Size())
+	if old, exists := e.data[key]; exists {
+		delta -= int64(old.Size())
 	}
+	e.stats.updateMemoryUsage(delta)
 
 	// 再次检查内存（添加对象后的预估内存使用）
 	if e.config.BackgroundCleanupInterval == 0 {
-		// 预估新增对象的大小
-		estimatedSize := int64(obj.Size())
-		e.stats.updateMemoryUsage(estimatedSize)
-
 		// 检查是否超过内存阈值
 		if err := internal.CheckMemoryAvailability(e.config.MemoryThreshold); err != nil {
 			// 回滚内存使用统计
-			e.stats.updateMemoryUsage(-estimatedSize)
+			e.stats.updateMemoryUsage(-delta)
 			return fmt.Errorf("insufficient memory for new object: %w", err)
 		}
 	}
 
+	if _, exists := e.data[key]; !exists {
+		e.scanAdd(key)
+	}
 	e.data[key] = obj
+	delete(e.reserved, key)
 	e.policy.Set(key)
+	e.trackExpiry(key, obj)
+	e.indexSet(key, obj)
+	e.notifyWatchers(key, obj)
 	e.stats.recordSet()
+	if len(e.data) > e.peakSize {
+		e.peakSize = len(e.data)
+	}
+
+	// 首次越过软限制时触发OnSoftLimit，回落到softRearm以下后再次越过会重新触发
+	if e.softLimit > 0 && !e.softHit && len(e.data) >= e.softLimit {
+		e.softHit = true
+		if e.config.OnSoftLimit != nil {
+			n.softSize = len(e.data)
+		}
+	}
 
+	e.evictForMemory(key, n)
+	return nil
+}
+
+// memoryLimit 返回触发按内存淘汰的字节数（MemoryThreshold * MaxMemoryBytes），0表示未启用
+func (e *StorageEngine) memoryLimit() int64 {
+	if e.config.MaxMemoryBytes <= 0 {
+		return 0
+	}
+	ratio := e.config.MemoryThreshold
+	if ratio <= 0 {
+		ratio = 1
+	}
+	return int64(ratio * float64(e.config.MaxMemoryBytes))
+}
+
+// evictForMemory 估算内存超过上限时按淘汰策略循环淘汰，直到回到上限以下或只剩刚写入的键
+// 必须在持有写锁的情况下调用
+func (e *StorageEngine) evictForMemory(key string, n *setNotices) {
+	limit := e.memoryLimit()
+	if limit <= 0 {
+		return
+	}
+	for e.stats.memoryUsage.Load() > limit && len(e.data) > 1 {
+		evictedKey, evictedObj := e.evictOne()
+		if evictedKey == "" {
+			return
+		}
+		if e.config.OnEvict != nil {
+			n.memEvicted = append(n.memEvicted, keyedObject{key: evictedKey, obj: evictedObj})
+		}
+		if evictedKey == key {
+			return
+		}
+	}
+}
+
+// expirySetter 支持原地修改过期时间的对象
+type expirySetter interface {
+	SetExpiresAtFrom(expiresAt, now time.Time)
+}
+
+// applyMinTTL 将低于MinTTL的过期时间提升到下限，或按配置拒绝写入
+func (e *StorageEngine) applyMinTTL(obj interfaces.DataObject) error {
+	if e.config.MinTTL <= 0 {
+		return nil
+	}
+
+	expiresAt := obj.ExpiresAt()
+	if expiresAt.IsZero() {
+		return nil // 永久键不受影响
+	}
+
+	now := e.now()
+	floor := now.Add(e.config.MinTTL)
+	if !expiresAt.Before(floor) {
+		return nil
+	}
+	if e.config.RejectBelowMinTTL {
+		return fmt.Errorf("%w: minimum is %v", errors.ErrTTLBelowMinimum, e.config.MinTTL)
+	}
+	if setter, ok := obj.(expirySetter); ok {
+		setter.SetExpiresAtFrom(floor, now)
+	}
 	return nil
 }
 
 // Get Get object
 func (e *StorageEngine) Get(key string) (interfaces.DataObject, bool) {
+	if e.closed.Load() {
+		return nil, false
+	}
+	key = e.normalizeKey(key)
+
 	// 验证Parameter
 	if key == "" {
 		return nil, false
 	}
 
+	if e.accessLog != nil {
+		e.accessLog.record(key)
+	}
+
 	e.mu.RLock()
 	obj, exists := e.data[key]
 	e.mu.RUnlock()
@@ -134,33 +485,180 @@ func (e *StorageEngine) Get(key string) (interfaces.DataObject, bool) {
 	}
 
 	// Check expiration
-	if obj.IsExpired() {
-		e.deleteExpired(key)
+	if e.isExpired(obj) {
+		if !e.markExpired(key, obj) {
+			e.deleteExpired(key)
+		}
 		e.stats.recordMiss()
-		e.stats.recordExpiration()
 		return nil, false
 	}
 
+	if tracker, ok := obj.(accessTracker); ok {
+		tracker.UpdateAccess()
+	}
+	e.slideOnHit(key, obj)
 	e.policy.Access(key)
 	e.stats.recordHit()
 	return obj, true
 }
 
+// RenameIf 当src的当前字符串值等于expected时将其重命名为dst（覆盖dst已有的值），返回是否重命名
+// 比较与移动在同一次加锁内完成，src不存在、已过期、不是字符串或值已变化时不做任何修改
+func (e *StorageEngine) RenameIf(src, dst, expected string) bool {
+	if e.closed.Load() {
+		return false
+	}
+	src = e.normalizeKey(src)
+	dst = e.normalizeKey(dst)
+
+	if src == "" || dst == "" {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[src]
+	if !exists || e.isExpired(obj) {
+		return false
+	}
+	str, ok := obj.(*types.StringObject)
+	if !ok || str.Value() != expected {
+		return false
+	}
+	if src == dst {
+		return true
+	}
+
+	if old, exists := e.data[dst]; exists {
+		e.stats.updateMemoryUsage(-int64(old.Size()))
+		e.policy.Delete(dst)
+	}
+
+	delete(e.data, src)
+	e.policy.Delete(src)
+	e.indexRemove(src)
+	e.closeWatchers(src)
+
+	if _, exists := e.data[dst]; !exists {
+		e.scanAdd(dst)
+	}
+	e.data[dst] = obj
+	delete(e.reserved, dst)
+	e.policy.Set(dst)
+	e.trackExpiry(dst, obj)
+	e.indexSet(dst, obj)
+	e.notifyWatchers(dst, obj)
+	return true
+}
+
+// MGetTouch 在一次加锁内批量读取并提升键的访问顺序，只返回存在且未过期的键（以调用方传入的键为索引）
+// 过期键在释放锁后删除
+func (e *StorageEngine) MGetTouch(keys []string) map[string]interfaces.DataObject {
+	result := make(map[string]interfaces.DataObject, len(keys))
+	if e.closed.Load() {
+		return result
+	}
+
+	var expired []string
+
+	e.mu.RLock()
+	for _, requested := range keys {
+		key := e.normalizeKey(requested)
+		if e.accessLog != nil {
+			e.accessLog.record(key)
+		}
+		obj, exists := e.data[key]
+		if !exists {
+			e.stats.recordMiss()
+			continue
+		}
+		if e.isExpired(obj) {
+			if !e.markExpired(key, obj) {
+				expired = append(expired, key)
+			}
+			e.stats.recordMiss()
+			continue
+		}
+
+		if tracker, ok := obj.(accessTracker); ok {
+			tracker.UpdateAccess()
+		}
+		e.slideOnHit(key, obj)
+		e.policy.Access(key)
+		e.stats.recordHit()
+		result[requested] = obj
+	}
+	e.mu.RUnlock()
+
+	for _, key := range expired {
+		e.deleteExpired(key)
+	}
+	return result
+}
+
+// accessTracker 支持记录最后访问时间的对象
+type accessTracker interface {
+	AccessedAt() time.Time
+	UpdateAccess()
+}
+
+// isExpired 检查对象是否过期（包括TTL过期和闲置超时）
+func (e *StorageEngine) isExpired(obj interfaces.DataObject) bool {
+	if e.config.Clock == nil {
+		if obj.IsExpired() {
+			return true
+		}
+	} else if expiresAt := obj.ExpiresAt(); !expiresAt.IsZero() && e.now().After(expiresAt) {
+		return true
+	}
+
+	if e.config.IdleTimeout > 0 {
+		if tracker, ok := obj.(accessTracker); ok {
+			return e.now().Sub(tracker.AccessedAt()) > e.config.IdleTimeout
+		}
+	}
+	return false
+}
+
+// now 返回配置的Clock给出的当前时间，未配置时使用time.Now
+func (e *StorageEngine) now() time.Time {
+	if e.config.Clock != nil {
+		return e.config.Clock()
+	}
+	return time.Now()
+}
+
+// normalizeKey 按配置的KeyNormalizer规范化键
+func (e *StorageEngine) normalizeKey(key string) string {
+	if e.config.KeyNormalizer == nil {
+		return key
+	}
+	return e.config.KeyNormalizer(key)
+}
+
 // deleteExpired Synchronously delete expired key（避免竞态条件）
-func (e *StorageEngine) deleteExpired(key string) {
+// 持有写锁后重新检查，键已被其他读取方删除或已重新写入时不做任何事，只有实际删除时才计入一次过期统计
+func (e *StorageEngine) deleteExpired(key string) bool {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	if obj, exists := e.data[key]; exists && obj.IsExpired() {
-		// Return object to pool before deletion
-		e.returnObjectToPool(obj)
-		delete(e.data, key)
-		e.policy.Delete(key)
+	obj, exists := e.data[key]
+	if !exists || !e.isExpired(obj) {
+		return false
 	}
+	e.removeExpiredLocked(key, obj)
+	e.afterRemove()
+	return true
 }
 
 // Delete Delete object
 func (e *StorageEngine) Delete(key string) bool {
+	if e.closed.Load() {
+		return false
+	}
+	key = e.normalizeKey(key)
+
 	// 验证Parameter
 	if key == "" {
 		return false
@@ -170,43 +668,117 @@ func (e *StorageEngine) Delete(key string) bool {
 	defer e.mu.Unlock()
 
 	if obj, exists := e.data[key]; exists {
-		// 更新内存使用统计（如果启用了内存管理）
-		if e.config.BackgroundCleanupInterval == 0 {
-			e.stats.updateMemoryUsage(-int64(obj.Size()))
-		}
-
-		// Return object to pool before deletion
-		e.returnObjectToPool(obj)
-
+		// 更新内存使用统计
+		e.stats.updateMemoryUsage(-int64(obj.Size()))
 		delete(e.data, key)
 		e.policy.Delete(key)
+		e.indexRemove(key)
+		e.closeWatchers(key)
 		e.stats.recordDelete()
+		e.afterRemove()
 		return true
 	}
 
 	return false
 }
 
-// returnObjectToPool returns an object to the appropriate pool for reuse
-func (e *StorageEngine) returnObjectToPool(obj interfaces.DataObject) {
-	switch o := obj.(type) {
-	case *types.StringObject:
-		types.ReleaseStringObject(o)
-		e.stats.recordPoolHit()
-	case *types.ListObject:
-		types.ReleaseListObject(o)
-		e.stats.recordPoolHit()
-	case *types.HashObject:
-		types.ReleaseHashObject(o)
-		e.stats.recordPoolHit()
-	default:
-		// Object type not supported for pooling
-		e.stats.recordPoolAlloc()
+// DeleteMany 在一次加锁内删除多个键，返回删除的键数和每个键是否被删除
+// 结果map以调用方传入的键为索引，重复的键只计一次
+func (e *StorageEngine) DeleteMany(keys []string) (int, map[string]bool) {
+	results := make(map[string]bool, len(keys))
+	if e.closed.Load() {
+		return 0, results
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	removed := 0
+	for _, key := range keys {
+		if results[key] {
+			continue
+		}
+		normalized := e.normalizeKey(key)
+		obj, exists := e.data[normalized]
+		if normalized == "" || !exists {
+			results[key] = false
+			continue
+		}
+
+		e.stats.updateMemoryUsage(-int64(obj.Size()))
+
+		delete(e.data, normalized)
+		e.policy.Delete(normalized)
+		e.indexRemove(normalized)
+		e.closeWatchers(normalized)
+		e.stats.recordDelete()
+		results[key] = true
+		removed++
+	}
+
+	if removed > 0 {
+		e.afterRemove()
+	}
+	return removed, results
+}
+
+// GetAndDelete 在同一次加锁内读取并删除对象
+// 指定dataTypes时仅删除类型匹配的对象，类型不匹配按未命中处理且保留原值
+// 返回的对象不会放回对象池，调用方可继续读取
+func (e *StorageEngine) GetAndDelete(key string, dataTypes ...interfaces.DataType) (interfaces.DataObject, bool) {
+	if e.closed.Load() {
+		return nil, false
+	}
+	key = e.normalizeKey(key)
+
+	if key == "" {
+		return nil, false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists {
+		e.stats.recordMiss()
+		return nil, false
+	}
+
+	if e.isExpired(obj) {
+		e.stats.updateMemoryUsage(-int64(obj.Size()))
+		delete(e.data, key)
+		e.policy.Delete(key)
+		e.indexRemove(key)
+		e.closeWatchers(key)
+		e.afterRemove()
+		e.stats.recordMiss()
+		e.stats.recordExpiration()
+		return nil, false
+	}
+
+	if len(dataTypes) > 0 && !slices.Contains(dataTypes, obj.Type()) {
+		e.stats.recordMiss()
+		return nil, false
 	}
+
+	e.stats.updateMemoryUsage(-int64(obj.Size()))
+	delete(e.data, key)
+	e.policy.Delete(key)
+	e.indexRemove(key)
+	e.closeWatchers(key)
+	e.stats.recordHit()
+	e.stats.recordDelete()
+	e.afterRemove()
+	return obj, true
 }
 
 // Exists Check if key exists
 func (e *StorageEngine) Exists(key string) bool {
+	if e.closed.Load() {
+		return false
+	}
+	key = e.normalizeKey(key)
+
 	// 验证Parameter
 	if key == "" {
 		return false
@@ -220,8 +792,10 @@ func (e *StorageEngine) Exists(key string) bool {
 		return false
 	}
 
-	if obj.IsExpired() {
-		e.deleteExpired(key)
+	if e.isExpired(obj) {
+		if !e.markExpired(key, obj) {
+			e.deleteExpired(key)
+		}
 		return false
 	}
 
@@ -230,6 +804,9 @@ func (e *StorageEngine) Exists(key string) bool {
 
 // Keys Get all keys
 func (e *StorageEngine) Keys() []string {
+	if e.closed.Load() {
+		return nil
+	}
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
@@ -240,24 +817,89 @@ func (e *StorageEngine) Keys() []string {
 	return keys
 }
 
+// KeysByType 返回指定类型的所有未过期键
+func (e *StorageEngine) KeysByType(dt interfaces.DataType) []string {
+	if e.closed.Load() {
+		return nil
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for key, obj := range e.data {
+		if obj.Type() == dt && !e.isExpired(obj) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// KeysMatch 返回匹配glob模式（path.Match语法，支持*、?和[...]）的未过期键（已排序），空模式等同于*
+// 在读锁内边遍历边过滤，不复制完整的键集合；模式格式错误时返回nil
+func (e *StorageEngine) KeysMatch(pattern string) []string {
+	if e.closed.Load() {
+		return nil
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil
+	}
+
+	e.mu.RLock()
+	keys := make([]string, 0)
+	for key, obj := range e.data {
+		if matched, _ := path.Match(pattern, key); matched && !e.isExpired(obj) {
+			keys = append(keys, key)
+		}
+	}
+	e.mu.RUnlock()
+
+	slices.Sort(keys)
+	return keys
+}
+
+// KeysPage 按键排序分页返回（page从1开始）
+func (e *StorageEngine) KeysPage(page, pageSize int) types.KeyPage {
+	return types.PageKeys(e.Keys(), page, pageSize)
+}
+
 // Flush 清空所有数据
+// 顺序保证：Flush在写锁内整体替换数据，对并发的Get/Set是线性一致的——
+// 在Flush之前完成的写入都会被清除，在Flush返回之后开始的写入都会保留；
+// Flush之前读取到的对象不会被放回对象池，读取方持有的引用不会被复用或清空
 func (e *StorageEngine) Flush() error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	// Return all objects to pool before clearing
-	for _, obj := range e.data {
-		e.returnObjectToPool(obj)
-	}
-
 	e.data = make(map[string]interfaces.DataObject, len(e.data))
+	e.reserved = nil
+	e.scanReset()
+	for _, idx := range e.indexes {
+		idx.buckets = make(map[string]map[string]struct{})
+		idx.byKey = make(map[string]string)
+	}
+	e.peakSize = 0
+	e.full = false
+	e.softHit = false
 	e.policy.Clear()
 	e.stats.reset()
+	e.closeAllWatchers()
+	e.markMu.Lock()
+	e.marked = nil
+	e.markMu.Unlock()
 	return nil
 }
 
 // Size 返回当前大小
 func (e *StorageEngine) Size() int {
+	if e.closed.Load() {
+		return 0
+	}
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 	return len(e.data)
@@ -265,6 +907,11 @@ func (e *StorageEngine) Size() int {
 
 // Type Get key type
 func (e *StorageEngine) Type(key string) (interfaces.DataType, bool) {
+	if e.closed.Load() {
+		return "", false
+	}
+	key = e.normalizeKey(key)
+
 	e.mu.RLock()
 	obj, exists := e.data[key]
 	e.mu.RUnlock()
@@ -273,8 +920,10 @@ func (e *StorageEngine) Type(key string) (interfaces.DataType, bool) {
 		return "", false
 	}
 
-	if obj.IsExpired() {
-		e.deleteExpired(key)
+	if e.isExpired(obj) {
+		if !e.markExpired(key, obj) {
+			e.deleteExpired(key)
+		}
 		return "", false
 	}
 
@@ -283,35 +932,132 @@ func (e *StorageEngine) Type(key string) (interfaces.DataType, bool) {
 
 // Expire Set expiration time
 func (e *StorageEngine) Expire(key string, ttl time.Duration) bool {
+	if e.closed.Load() {
+		return false
+	}
+	key = e.normalizeKey(key)
+
+	ttl, ok := e.clampTTL(ttl)
+	if !ok {
+		return false
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	obj, exists := e.data[key]
-	if !exists {
+	if !exists || e.isExpired(obj) {
+		return false
+	}
+	if t, ok := obj.(tombstoner); ok && t.Tombstoned() {
 		return false
 	}
 
 	// 创建新的对象以更新过期时间
+	var newObj interfaces.DataObject
 	switch t := obj.(type) {
 	case *types.StringObject:
-		newObj := types.NewStringObject(t.Value(), ttl)
-		e.data[key] = newObj
-		return true
+		newObj = types.NewStringObject(t.Value(), ttl)
 	case *types.ListObject:
-		newObj := types.NewListObject(t.Values(), ttl)
-		e.data[key] = newObj
-		return true
+		newObj = types.NewListObject(t.Values(), ttl)
+	case *types.CircularListObject:
+		newObj = types.NewCircularListObject(t.Capacity(), t.Values(), ttl)
 	case *types.HashObject:
-		newObj := types.NewHashObject(t.Fields(), ttl)
-		e.data[key] = newObj
-		return true
+		newObj = types.NewHashObject(t.Fields(), ttl)
+	case *types.SetObject:
+		newObj = types.NewSetObject(t.Members(), ttl)
+	default:
+		return false
+	}
+	if setter, ok := newObj.(expirySetter); ok && ttl > 0 {
+		now := e.now()
+		setter.SetExpiresAtFrom(now.Add(ttl), now)
 	}
 
-	return false
+	e.data[key] = newObj
+	e.trackExpiry(key, newObj)
+	e.indexSet(key, newObj)
+	return true
+}
+
+// ExpireMatching 为所有匹配glob模式（path.Match语法）的未过期键原地设置新的TTL，返回更新的键数
+// ttl为0表示设为永不过期
+func (e *StorageEngine) ExpireMatching(pattern string, ttl time.Duration) int {
+	if e.closed.Load() {
+		return 0
+	}
+	ttl, ok := e.clampTTL(ttl)
+	if !ok {
+		return 0
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = e.now().Add(ttl)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	updated := 0
+	for key, obj := range e.data {
+		if matched, err := path.Match(pattern, key); err != nil || !matched {
+			continue
+		}
+		setter, ok := obj.(expirySetter)
+		if !ok || e.isExpired(obj) {
+			continue
+		}
+		setter.SetExpiresAtFrom(expiresAt, e.now())
+		e.trackExpiry(key, obj)
+		updated++
+	}
+	return updated
+}
+
+// clampTTL 应用MinTTL下限，按配置拒绝时返回false
+func (e *StorageEngine) clampTTL(ttl time.Duration) (time.Duration, bool) {
+	if ttl > 0 && ttl < e.config.MinTTL {
+		if e.config.RejectBelowMinTTL {
+			return 0, false
+		}
+		ttl = e.config.MinTTL
+	}
+	return ttl, true
+}
+
+// slider 支持滑动过期的对象
+type slider interface {
+	SlideAt(now time.Time) bool
+}
+
+// slideOnHit 键匹配SlidingTTLPatterns时将过期时间顺延为写入时的TTL
+func (e *StorageEngine) slideOnHit(key string, obj interfaces.DataObject) {
+	for _, pattern := range e.config.SlidingTTLPatterns {
+		if matched, _ := path.Match(pattern, key); !matched {
+			continue
+		}
+		if s, ok := obj.(slider); ok && s.SlideAt(e.now()) {
+			e.trackExpiry(key, obj)
+		}
+		return
+	}
+}
+
+// trackExpiry 将键的过期时间告知感知过期的淘汰策略
+func (e *StorageEngine) trackExpiry(key string, obj interfaces.DataObject) {
+	if p, ok := e.policy.(interfaces.ExpiryAwarePolicy); ok {
+		p.SetExpiry(key, obj.ExpiresAt())
+	}
 }
 
 // TTL 获取剩余生存时间
 func (e *StorageEngine) TTL(key string) (time.Duration, bool) {
+	if e.closed.Load() {
+		return -1, false
+	}
+	key = e.normalizeKey(key)
+
 	// 验证Parameter
 	if key == "" {
 		return -1, false
@@ -325,7 +1071,66 @@ func (e *StorageEngine) TTL(key string) (time.Duration, bool) {
 		return -1, false
 	}
 
-	return utils.CalculateRemainingTTL(obj.ExpiresAt())
+	return utils.CalculateRemainingTTLAt(obj.ExpiresAt(), e.now())
+}
+
+// MTTL 在一次加锁内按位置返回多个键的剩余生存时间（秒，向下取整），
+// -1表示永不过期，-2表示键不存在或已过期
+func (e *StorageEngine) MTTL(keys ...string) []int {
+	result := make([]int, len(keys))
+	if e.closed.Load() {
+		for i := range result {
+			result[i] = constants.KeyMissing
+		}
+		return result
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for i, key := range keys {
+		obj, exists := e.data[e.normalizeKey(key)]
+		if !exists || e.isExpired(obj) {
+			result[i] = constants.KeyMissing
+			continue
+		}
+
+		expiresAt := obj.ExpiresAt()
+		if expiresAt.IsZero() {
+			result[i] = constants.NoExpiration
+			continue
+		}
+		result[i] = int(expiresAt.Sub(e.now()) / time.Second)
+	}
+	return result
+}
+
+// ExpireTime 获取绝对过期时间，零值表示永不过期
+func (e *StorageEngine) ExpireTime(key string) (time.Time, bool) {
+	if e.closed.Load() {
+		return time.Time{}, false
+	}
+	key = e.normalizeKey(key)
+	if key == "" {
+		return time.Time{}, false
+	}
+
+	e.mu.RLock()
+	obj, exists := e.data[key]
+	e.mu.RUnlock()
+
+	if !exists {
+		return time.Time{}, false
+	}
+
+	if e.isExpired(obj) {
+		if !e.markExpired(key, obj) {
+			e.deleteExpired(key)
+		}
+		return time.Time{}, false
+	}
+
+	return obj.ExpiresAt(), true
 }
 
 // Stats Get statistics
@@ -342,48 +1147,110 @@ func (e *StorageEngine) Stats() interface{} {
 		e.stats.updateGCCycles(int64(memStats.NumGC))
 	}
 
-	return map[string]interface{}{
-		"hits":         e.stats.hits,
-		"misses":       e.stats.misses,
-		"sets":         e.stats.sets,
-		"deletes":      e.stats.deletes,
-		"evictions":    e.stats.evictions,
-		"expirations":  e.stats.expirations,
-		"memory":       e.stats.memoryUsage,
+	// 原子读取计数器快照，避免与record*方法发生数据竞争
+	snap := e.stats.snapshot()
+
+	result := map[string]interface{}{
+		"hits":         snap.hits,
+		"misses":       snap.misses,
+		"sets":         snap.sets,
+		"deletes":      snap.deletes,
+		"evictions":    snap.evictions,
+		"expirations":  snap.expirations,
+		"memory":       snap.memoryUsage,
 		"keys":         len(e.data),
-		"hit_rate":     e.stats.hitRate(),
-		"gc_cycles":    e.stats.gcCycles,
-		"pool_hits":    e.stats.poolHits,
-		"pool_allocs":  e.stats.poolAllocs,
+		"hit_rate":     snap.hitRate(),
+		"gc_cycles":    snap.gcCycles,
+		"pool_hits":    snap.poolHits,
+		"pool_allocs":  snap.poolAllocs,
 		"heap_alloc":   memStats.HeapAlloc,
 		"heap_sys":     memStats.HeapSys,
 		"num_gc":       memStats.NumGC,
 		"gc_cpu_frac":  memStats.GCCPUFraction,
 	}
+
+	result["policy"] = e.policy.Stats()
+	result["admission_rejects"] = snap.rejections
+	result["policy_name"] = policyName(e.policy)
+	result["max_size"] = e.config.MaxSize
+	result["memory_limit"] = e.memoryLimit()
+	result["default_expiration"] = e.config.DefaultExpiration
+
+	uptime := time.Since(e.startedAt)
+	result["started_at"] = e.startedAt
+	result["uptime"] = uptime
+	if seconds := uptime.Seconds(); seconds > 0 {
+		ops := snap.hits + snap.misses + snap.sets + snap.deletes
+		result["ops_per_sec"] = float64(ops) / seconds
+	}
+	result["reserved"] = len(e.reserved)
+	if e.config.TwoPhaseDelete {
+		result["pending_sweep"] = e.pendingSweep()
+	}
+	if e.config.BackgroundCleanupInterval > 0 {
+		result["cleanup_interval"] = e.CleanupInterval()
+	}
+
+	if e.config.StatsWindow > 0 {
+		result["window_hit_rate"] = e.stats.windowHitRate()
+	}
+
+	return result
 }
 
-// evictOne 淘汰一个键
-func (e *StorageEngine) evictOne() {
-	if key := e.policy.Evict(); key != "" {
-		if obj, exists := e.data[key]; exists {
-			// Return object to pool before eviction
-			e.returnObjectToPool(obj)
-		}
-		delete(e.data, key)
-		e.stats.recordEviction()
+// policyName 返回策略名称，未实现NamedPolicy时使用类型名
+func policyName(policy interfaces.EvictionPolicy) string {
+	if named, ok := policy.(interfaces.NamedPolicy); ok {
+		return named.Name()
 	}
+	return fmt.Sprintf("%T", policy)
+}
+
+// evictOne 淘汰一个键，返回被淘汰的键和对象（未淘汰时返回空）
+// 被淘汰的对象不会被回收复用，可交给OnEvict回调使用
+func (e *StorageEngine) evictOne() (string, interfaces.DataObject) {
+	key := e.policy.Evict()
+	if key == "" {
+		return "", nil
+	}
+
+	obj, exists := e.data[key]
+	if exists {
+		e.stats.updateMemoryUsage(-int64(obj.Size()))
+	}
+	delete(e.data, key)
+	e.indexRemove(key)
+	e.closeWatchers(key)
+	e.stats.recordEviction()
+	return key, obj
+}
+
+// notifyEvict 调用OnEvict回调，必须在释放锁之后调用
+func (e *StorageEngine) notifyEvict(key string, obj interfaces.DataObject) {
+	if e.config.OnEvict != nil && obj != nil {
+		e.config.OnEvict(key, obj)
+	}
+}
+
+// cleanupJitter 返回首次清理前的随机延迟，避免同时创建的引擎同步清理
+func (e *StorageEngine) cleanupJitter() time.Duration {
+	if jitter := e.config.CleanupJitterRange(); jitter > 0 {
+		return rand.N(jitter)
+	}
+	return 0
 }
 
 // startBackgroundCleanup 启动后台清理
 func (e *StorageEngine) startBackgroundCleanup() {
 	go func() {
-		ticker := time.NewTicker(e.config.BackgroundCleanupInterval)
-		defer ticker.Stop()
+		timer := time.NewTimer(e.CleanupInterval() + e.cleanupJitter())
+		defer timer.Stop()
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-timer.C:
 				e.cleanupExpired()
+				timer.Reset(e.CleanupInterval())
 			case <-e.stopChan:
 				return
 			case <-e.bgCleanup:
@@ -393,20 +1260,156 @@ func (e *StorageEngine) startBackgroundCleanup() {
 	}()
 }
 
-// cleanupExpired 清理过期项目
+// cleanupExpired 清理过期项目，配置了OnExpire时过期键交给回调决定刷新还是删除
 func (e *StorageEngine) cleanupExpired() {
+	if expired := e.removeExpired(); len(expired) > 0 {
+		e.refreshExpired(expired)
+	}
+}
+
+// keyedObject 键及其对象，用于在释放锁之后交给回调处理
+type keyedObject struct {
+	key string
+	obj interfaces.DataObject
+}
+
+// removeExpired 删除过期键并按回收比例调整清理间隔，配置了OnExpire时返回过期键而不删除
+func (e *StorageEngine) removeExpired() []keyedObject {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	scanned := len(e.data)
+	reclaimed := e.sweepLocked()
+	var expired []keyedObject
 	for key, obj := range e.data {
-		if obj.IsExpired() {
-			// Return object to pool before deletion
-			e.returnObjectToPool(obj)
-			delete(e.data, key)
-			e.policy.Delete(key)
-			e.stats.recordExpiration()
+		if e.isExpired(obj) {
+			expired = append(expired, keyedObject{key: key, obj: obj})
 		}
 	}
+	if e.config.DeterministicEviction {
+		slices.SortFunc(expired, func(a, b keyedObject) int { return strings.Compare(a.key, b.key) })
+	}
+	if e.config.OnExpire == nil {
+		for _, entry := range expired {
+			e.removeExpiredLocked(entry.key, entry.obj)
+		}
+		reclaimed += len(expired)
+		expired = nil
+	}
+	e.afterRemove()
+
+	if e.config.AdaptiveCleanup {
+		e.adaptCleanupInterval(scanned, reclaimed+len(expired))
+	}
+	return expired
+}
+
+// refreshExpired 在锁外调用OnExpire，再加锁用返回的对象刷新键或删除键
+// 回调期间键被重新写入、删除或延长了过期时间的，以当前状态为准，忽略回调结果
+func (e *StorageEngine) refreshExpired(expired []keyedObject) {
+	replacements := make([]interfaces.DataObject, len(expired))
+	for i, entry := range expired {
+		if obj, ok := e.config.OnExpire(entry.key, entry.obj); ok && obj != nil {
+			e.rebaseExpiry(obj)
+			replacements[i] = obj
+		}
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	removed := false
+	for i, entry := range expired {
+		if cur, exists := e.data[entry.key]; !exists || cur != entry.obj || !e.isExpired(cur) {
+			continue
+		}
+		if obj := replacements[i]; obj != nil && e.applyMinTTL(obj) == nil && e.setLocked(entry.key, obj, &notices) == nil {
+			continue
+		}
+		e.removeExpiredLocked(entry.key, entry.obj)
+		removed = true
+	}
+	if removed {
+		e.afterRemove()
+	}
+}
+
+// adaptCleanupInterval 按一轮清理的回收比例调整清理间隔：回收多时减半，回收少时加倍，限制在上下限之间
+func (e *StorageEngine) adaptCleanupInterval(scanned, reclaimed int) {
+	ratio := 0.0
+	if scanned > 0 {
+		ratio = float64(reclaimed) / float64(scanned)
+	}
+
+	current := e.CleanupInterval()
+	next := current
+	switch {
+	case ratio >= constants.AdaptiveCleanupShrinkRatio:
+		next = current / 2
+	case ratio <= constants.AdaptiveCleanupGrowRatio:
+		next = current * 2
+	}
+
+	minInterval, maxInterval := e.config.CleanupIntervalBounds()
+	next = min(max(next, minInterval), maxInterval)
+	if next == current {
+		return
+	}
+
+	e.interval.Store(int64(next))
+	if e.cleanup != nil {
+		e.cleanup.SetInterval(next)
+	}
+}
+
+// CleanupInterval 返回当前后台清理间隔，未启用后台清理时返回0
+func (e *StorageEngine) CleanupInterval() time.Duration {
+	return time.Duration(e.interval.Load())
+}
+
+// Compact 按存活键数重建底层map，回收大量删除后map不会收缩的内存
+func (e *StorageEngine) Compact() {
+	if e.closed.Load() {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.compactLocked()
+}
+
+// compactLocked 重建map，必须在持有写锁的情况下调用
+func (e *StorageEngine) compactLocked() {
+	data := make(map[string]interfaces.DataObject, len(e.data))
+	for key, obj := range e.data {
+		data[key] = obj
+	}
+	e.data = data
+	e.peakSize = len(data)
+}
+
+// afterRemove 删除键后的维护工作，必须在持有写锁的情况下调用
+func (e *StorageEngine) afterRemove() {
+	if e.full && len(e.data) < e.config.MaxSize {
+		e.full = false
+	}
+	if e.softHit && len(e.data) < e.softRearm {
+		e.softHit = false
+	}
+	e.maybeCompact()
+}
+
+// maybeCompact 当负载因子低于CompactThreshold时自动压缩，必须在持有写锁的情况下调用
+func (e *StorageEngine) maybeCompact() {
+	threshold := e.config.CompactThreshold
+	if threshold <= 0 || e.peakSize < constants.MinCompactSize {
+		return
+	}
+	if float64(len(e.data)) < float64(e.peakSize)*threshold {
+		e.compactLocked()
+	}
 }
 
 // GetConfig 获取引擎配置
@@ -414,72 +1417,156 @@ func (e *StorageEngine) GetConfig() *config.EngineConfig {
 	return e.config
 }
 
-// Close 关闭引擎
-func (e *StorageEngine) Close() {
+// Close 关闭引擎并停止后台清理，可重复调用
+// 关闭后返回error的方法返回ErrCacheClosed，其余方法按未命中处理（Stats仍可读取）
+func (e *StorageEngine) Close() error {
+	if e.closed.Swap(true) {
+		return nil // 重复关闭是安全的
+	}
+
+	if e.cleanup != nil {
+		e.cleanup.Stop()
+	}
 	close(e.stopChan)
+
+	e.mu.Lock()
+	e.closeAllWatchers()
+	e.mu.Unlock()
+	return nil
+}
+
+// Closed 返回引擎是否已关闭
+func (e *StorageEngine) Closed() bool {
+	return e.closed.Load()
 }
 
 // EngineStats Method实现
 
 func (s *EngineStats) recordHit() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.hits++
+	s.hits.Add(1)
+	if s.window > 0 {
+		s.bucket().hits.Add(1)
+	}
 }
 
 func (s *EngineStats) recordMiss() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.misses++
+	s.misses.Add(1)
+	if s.window > 0 {
+		s.bucket().misses.Add(1)
+	}
+}
+
+// bucket 返回当前窗口桶，必要时先轮换
+func (s *EngineStats) bucket() *windowBucket {
+	s.rotate(s.now())
+	return &s.buckets[s.current.Load()]
+}
+
+// rotate 当前窗口到期时切换到另一个桶并清零；超过两个窗口未更新则清空全部桶
+func (s *EngineStats) rotate(now int64) {
+	start := s.windowStart.Load()
+	elapsed := time.Duration(now - start)
+	if elapsed < s.window {
+		return
+	}
+	if !s.windowStart.CompareAndSwap(start, now) {
+		return // 其他goroutine已完成轮换
+	}
+
+	next := 1 - s.current.Load()
+	if elapsed >= 2*s.window {
+		s.buckets[1-next].reset()
+	}
+	s.buckets[next].reset()
+	s.current.Store(next)
+}
+
+func (b *windowBucket) reset() {
+	b.hits.Store(0)
+	b.misses.Store(0)
+}
+
+// windowHitRate 返回最近一到两个窗口内的命中率
+func (s *EngineStats) windowHitRate() float64 {
+	hits, misses := s.windowCounts()
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// windowCounts 返回最近一到两个窗口内的命中和未命中次数
+func (s *EngineStats) windowCounts() (hits, misses int64) {
+	s.rotate(s.now())
+	hits = s.buckets[0].hits.Load() + s.buckets[1].hits.Load()
+	misses = s.buckets[0].misses.Load() + s.buckets[1].misses.Load()
+	return hits, misses
 }
 
 func (s *EngineStats) recordSet() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.sets++
+	s.sets.Add(1)
 }
 
 func (s *EngineStats) recordDelete() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.deletes++
+	s.deletes.Add(1)
 }
 
 func (s *EngineStats) recordEviction() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.evictions++
+	s.evictions.Add(1)
 }
 
 func (s *EngineStats) recordExpiration() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.expirations++
-}
-
-func (s *EngineStats) recordPoolHit() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.poolHits++
+	s.expirations.Add(1)
 }
 
-func (s *EngineStats) recordPoolAlloc() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.poolAllocs++
+func (s *EngineStats) recordRejection() {
+	s.rejections.Add(1)
 }
 
 func (s *EngineStats) updateGCCycles(cycles int64) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.gcCycles = cycles
-	s.lastGCTime = time.Now()
+	s.gcCycles.Store(cycles)
+	s.lastGCTime.Store(time.Now().UnixNano())
 }
 
 func (s *EngineStats) hitRate() float64 {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	snap := s.snapshot()
+	return snap.hitRate()
+}
+
+// statsSnapshot 统计计数器的快照
+type statsSnapshot struct {
+	hits        int64
+	misses      int64
+	sets        int64
+	deletes     int64
+	evictions   int64
+	expirations int64
+	rejections  int64
+	memoryUsage int64
+	gcCycles    int64
+	poolHits    int64
+	poolAllocs  int64
+}
+
+// snapshot 原子读取所有计数器
+func (s *EngineStats) snapshot() statsSnapshot {
+	return statsSnapshot{
+		hits:        s.hits.Load(),
+		misses:      s.misses.Load(),
+		sets:        s.sets.Load(),
+		deletes:     s.deletes.Load(),
+		evictions:   s.evictions.Load(),
+		expirations: s.expirations.Load(),
+		rejections:  s.rejections.Load(),
+		memoryUsage: s.memoryUsage.Load(),
+		gcCycles:    s.gcCycles.Load(),
+		poolHits:    s.poolHits.Load(),
+		poolAllocs:  s.poolAllocs.Load(),
+	}
+}
 
+// hitRate 基于快照计算命中率，命中与未命中取自同一快照
+func (s statsSnapshot) hitRate() float64 {
 	total := s.hits + s.misses
 	if total == 0 {
 		return 0
@@ -488,22 +1575,22 @@ func (s *EngineStats) hitRate() float64 {
 }
 
 func (s *EngineStats) reset() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.hits = 0
-	s.misses = 0
-	s.sets = 0
-	s.deletes = 0
-	s.evictions = 0
-	s.expirations = 0
-	s.gcCycles = 0
-	s.poolHits = 0
-	s.poolAllocs = 0
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.sets.Store(0)
+	s.deletes.Store(0)
+	s.evictions.Store(0)
+	s.expirations.Store(0)
+	s.rejections.Store(0)
+	s.gcCycles.Store(0)
+	s.poolHits.Store(0)
+	s.poolAllocs.Store(0)
+	s.memoryUsage.Store(0)
+	s.buckets[0].reset()
+	s.buckets[1].reset()
 }
 
 // updateMemoryUsage 更新内存使用统计
 func (s *EngineStats) updateMemoryUsage(delta int64) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.memoryUsage += delta
+	s.memoryUsage.Add(delta)
 }
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// fieldIndex Hash字段二级索引：字段值 -> 键集合
+type fieldIndex struct {
+	pattern string                         // 参与索引的键的glob模式
+	field   string                         // 被索引的字段
+	buckets map[string]map[string]struct{} // 字段值 -> 键集合
+	byKey   map[string]string              // 键 -> 当前所在的字段值
+}
+
+// indexValue 将字段值转换为索引桶的键
+func indexValue(value interface{}) string {
+	return fmt.Sprint(value)
+}
+
+// add 将键加入对应字段值的桶（先移出旧桶）
+func (idx *fieldIndex) add(key string, obj interfaces.DataObject) {
+	idx.remove(key)
+
+	hash, ok := obj.(*types.HashObject)
+	if !ok {
+		return
+	}
+	if matched, err := path.Match(idx.pattern, key); err != nil || !matched {
+		return
+	}
+	value, exists := hash.Get(idx.field)
+	if !exists {
+		return
+	}
+
+	v := indexValue(value)
+	bucket := idx.buckets[v]
+	if bucket == nil {
+		bucket = make(map[string]struct{})
+		idx.buckets[v] = bucket
+	}
+	bucket[key] = struct{}{}
+	idx.byKey[key] = v
+}
+
+// remove 将键移出索引
+func (idx *fieldIndex) remove(key string) {
+	v, exists := idx.byKey[key]
+	if !exists {
+		return
+	}
+	delete(idx.byKey, key)
+
+	bucket := idx.buckets[v]
+	delete(bucket, key)
+	if len(bucket) == 0 {
+		delete(idx.buckets, v)
+	}
+}
+
+// CreateIndex 为匹配keyPattern（path.Match语法）的Hash键的field字段建立二级索引
+// 索引在Set/HSet/HDel/Delete/过期/淘汰时自动维护，对同一字段重复调用会按新模式重建
+func (e *StorageEngine) CreateIndex(keyPattern, field string) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+	if _, err := path.Match(keyPattern, ""); err != nil {
+		return fmt.Errorf("%w: %v", errors.ErrInvalidArgument, err)
+	}
+	if field == "" {
+		return fmt.Errorf("%w: field cannot be empty", errors.ErrInvalidArgument)
+	}
+
+	idx := &fieldIndex{
+		pattern: keyPattern,
+		field:   field,
+		buckets: make(map[string]map[string]struct{}),
+		byKey:   make(map[string]string),
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, obj := range e.data {
+		if !e.isExpired(obj) {
+			idx.add(key, obj)
+		}
+	}
+
+	if e.indexes == nil {
+		e.indexes = make(map[string]*fieldIndex)
+	}
+	e.indexes[field] = idx
+	return nil
+}
+
+// LookupIndex 返回field字段等于value的所有未过期键（已排序），字段未建立索引时返回nil
+func (e *StorageEngine) LookupIndex(field string, value interface{}) []string {
+	if e.closed.Load() {
+		return nil
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	idx, exists := e.indexes[field]
+	if !exists {
+		return nil
+	}
+
+	bucket := idx.buckets[indexValue(value)]
+	keys := make([]string, 0, len(bucket))
+	for key := range bucket {
+		if obj, exists := e.data[key]; exists && !e.isExpired(obj) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HSet 设置Hash字段并维护索引，键不存在或已过期时创建永不过期的Hash
+func (e *StorageEngine) HSet(key, field string, value interface{}) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 在同一次加锁内创建Hash，避免并发HSet同一个新键时互相覆盖
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return e.setLocked(key, types.NewHashObject(map[string]interface{}{field: value}, 0), &notices)
+	}
+
+	hash, ok := obj.(*types.HashObject)
+	if !ok {
+		return errors.ErrTypeMismatch
+	}
+	before := hash.Size()
+	hash.Set(field, value)
+	e.stats.updateMemoryUsage(int64(hash.Size() - before))
+	e.indexSet(key, hash)
+	e.notifyWatchers(key, hash)
+	return nil
+}
+
+// HDel 删除Hash字段并维护索引，最后一个字段被删除时删除整个键
+func (e *StorageEngine) HDel(key, field string) bool {
+	return e.HDelMany(key, field) > 0
+}
+
+// HDelMany 删除多个Hash字段并返回实际删除的字段数，最后一个字段被删除时删除整个键
+func (e *StorageEngine) HDelMany(key string, fields ...string) int {
+	if e.closed.Load() {
+		return 0
+	}
+	key = e.normalizeKey(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return 0
+	}
+	hash, ok := obj.(*types.HashObject)
+	if !ok {
+		return 0
+	}
+	before := hash.Size()
+	removed := 0
+	for _, field := range fields {
+		if hash.Delete(field) {
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0
+	}
+	if hash.Len() == 0 {
+		e.stats.updateMemoryUsage(-int64(before))
+		e.removeLocked(key, hash)
+		return removed
+	}
+	e.stats.updateMemoryUsage(int64(hash.Size() - before))
+	e.indexSet(key, hash)
+	e.notifyWatchers(key, hash)
+	return removed
+}
+
+// indexSet 键写入后更新所有索引，必须在持有写锁的情况下调用
+func (e *StorageEngine) indexSet(key string, obj interfaces.DataObject) {
+	for _, idx := range e.indexes {
+		idx.add(key, obj)
+	}
+}
+
+// indexRemove 键删除后更新所有索引，必须在持有写锁的情况下调用
+func (e *StorageEngine) indexRemove(key string) {
+	for _, idx := range e.indexes {
+		idx.remove(key)
+	}
+}
@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// LPushCap 在固定容量环形列表头部写入，写满时丢弃尾部元素；键不存在或已过期时按capacity创建永不过期的环形列表
+func (e *StorageEngine) LPushCap(key string, capacity int, value interface{}) error {
+	return e.pushCapped(key, capacity, value, true)
+}
+
+// RPushCap 在固定容量环形列表尾部写入，写满时丢弃头部元素；键不存在或已过期时按capacity创建永不过期的环形列表
+func (e *StorageEngine) RPushCap(key string, capacity int, value interface{}) error {
+	return e.pushCapped(key, capacity, value, false)
+}
+
+// pushCapped 写入环形列表，已存在的环形列表沿用原有容量
+func (e *StorageEngine) pushCapped(key string, capacity int, value interface{}, front bool) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+	if capacity < 1 {
+		return errors.ErrInvalidArgument
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 在同一次加锁内创建环形列表，避免并发写入同一个新键时互相覆盖
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return e.setLocked(key, types.NewCircularListObject(capacity, []interface{}{value}, 0), &notices)
+	}
+
+	ring, ok := obj.(*types.CircularListObject)
+	if !ok {
+		return errors.ErrTypeMismatch
+	}
+	e.stats.updateMemoryUsage(pushAll(ring, []interface{}{value}, front))
+	e.notifyWatchers(key, ring)
+	return nil
+}
+
+// LPush 依次在列表头部写入values（最后一个值位于头部），返回写入后的长度；键不存在或已过期时创建永不过期的列表
+func (e *StorageEngine) LPush(key string, values ...interface{}) (int, error) {
+	return e.push(key, values, true)
+}
+
+// RPush 依次在列表尾部写入values，返回写入后的长度；键不存在或已过期时创建永不过期的列表
+func (e *StorageEngine) RPush(key string, values ...interface{}) (int, error) {
+	return e.push(key, values, false)
+}
+
+// push 写入列表两端，已存在的环形列表按其容量丢弃另一端的元素
+func (e *StorageEngine) push(key string, values []interface{}, front bool) (int, error) {
+	if e.closed.Load() {
+		return 0, errors.ErrCacheClosed
+	}
+	if len(values) == 0 {
+		return 0, errors.ErrInvalidArgument
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return 0, err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		list := types.NewListObject(nil, 0)
+		pushAll(list, values, front)
+		if err := e.setLocked(key, list, &notices); err != nil {
+			return 0, err
+		}
+		return list.Len(), nil
+	}
+
+	list, ok := obj.(interfaces.ListObject)
+	if !ok {
+		return 0, errors.ErrTypeMismatch
+	}
+	e.stats.updateMemoryUsage(pushAll(list, values, front))
+	e.notifyWatchers(key, list)
+	return list.Len(), nil
+}
+
+// capacityLimited 固定容量的列表（环形列表），写满后写入一端会丢弃另一端的元素
+type capacityLimited interface {
+	Capacity() int
+}
+
+// pushAll 按顺序写入列表的一端，返回按写入元素计算的字节数变化，不遍历整个列表
+func pushAll(list interfaces.ListObject, values []interface{}, front bool) int64 {
+	var delta int64
+	for _, v := range values {
+		delta += int64(pushDelta(list, v, front))
+		if front {
+			list.PushFront(v)
+		} else {
+			list.Push(v)
+		}
+	}
+	return delta
+}
+
+// pushDelta 在列表一端写入value引起的字节数变化，已满的环形列表扣除从另一端丢弃的元素
+func pushDelta(list interfaces.ListObject, value interface{}, front bool) int {
+	n := list.Len()
+	if ring, ok := list.(capacityLimited); ok && n == ring.Capacity() {
+		drop := 0 // 尾部写入丢弃头部元素
+		if front {
+			drop = -1
+		}
+		dropped, _ := list.Index(drop)
+		return types.ElementSize(value, n-1) - types.ElementSize(dropped, n-1)
+	}
+	return types.ElementSize(value, n)
+}
+
+// LPop 移除并返回列表头部元素，取出最后一个元素时删除键
+func (e *StorageEngine) LPop(key string) (interface{}, bool) {
+	return e.pop(key, true)
+}
+
+// RPop 移除并返回列表尾部元素，取出最后一个元素时删除键
+func (e *StorageEngine) RPop(key string) (interface{}, bool) {
+	return e.pop(key, false)
+}
+
+// pop 从列表两端取出元素，键不存在、已过期或不是列表时返回false
+func (e *StorageEngine) pop(key string, front bool) (interface{}, bool) {
+	if e.closed.Load() {
+		return nil, false
+	}
+	key = e.normalizeKey(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return nil, false
+	}
+	list, ok := obj.(interfaces.ListObject)
+	if !ok {
+		return nil, false
+	}
+
+	end := interfaces.ListRight
+	if front {
+		end = interfaces.ListLeft
+	}
+	value, ok := e.popLocked(list, end)
+	if !ok {
+		return nil, false
+	}
+	if list.Len() == 0 {
+		e.stats.updateMemoryUsage(-int64(list.Size()))
+		e.removeLocked(key, list)
+		return value, true
+	}
+	e.notifyWatchers(key, list)
+	return value, true
+}
+
+// popLocked 从列表的指定一端取出元素，并按取出的元素扣减内存统计
+func (e *StorageEngine) popLocked(list interfaces.ListObject, end interfaces.ListEnd) (interface{}, bool) {
+	var value interface{}
+	var ok bool
+	if end == interfaces.ListLeft {
+		value, ok = list.PopFront()
+	} else {
+		value, ok = list.Pop()
+	}
+	if ok {
+		e.stats.updateMemoryUsage(-int64(types.ElementSize(value, list.Len())))
+	}
+	return value, ok
+}
+
+// LMove 在一次加锁内从src的srcEnd端取出元素并写入dst的dstEnd端，返回移动的元素
+// src不存在、已过期或为空时返回nil；src或dst不是列表时返回ErrTypeMismatch且不修改任何键
+// dst不存在时创建永不过期的列表，src与dst相同时在列表内轮转，取出最后一个元素时删除src
+func (e *StorageEngine) LMove(src, dst string, srcEnd, dstEnd interfaces.ListEnd) (interface{}, error) {
+	if e.closed.Load() {
+		return nil, errors.ErrCacheClosed
+	}
+	if !validListEnd(srcEnd) || !validListEnd(dstEnd) {
+		return nil, errors.ErrInvalidArgument
+	}
+	src, dst = e.normalizeKey(src), e.normalizeKey(dst)
+	if err := utils.ValidateCacheKey(dst); err != nil {
+		return nil, err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return lmoveLocked(e, e, src, dst, srcEnd, dstEnd, &notices)
+}
+
+// lmoveLocked 将src（位于from）的元素移动到dst（位于to），from与to可以是同一个引擎
+// 调用方已规范化键并持有两个引擎的写锁，写入dst产生的回调记录在notices中
+func lmoveLocked(from, to *StorageEngine, src, dst string, srcEnd, dstEnd interfaces.ListEnd, notices *setNotices) (interface{}, error) {
+	fromList, err := from.liveListLocked(src)
+	if err != nil || fromList == nil {
+		return nil, err
+	}
+	toList, err := to.liveListLocked(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := from.popLocked(fromList, srcEnd)
+	if !ok {
+		return nil, nil
+	}
+
+	if toList == nil {
+		if err := to.setLocked(dst, types.NewListObject([]interface{}{value}, 0), notices); err != nil {
+			// 写入dst失败时放回原位，两个键均保持不变
+			from.stats.updateMemoryUsage(pushAll(fromList, []interface{}{value}, srcEnd == interfaces.ListLeft))
+			return nil, err
+		}
+	} else {
+		to.stats.updateMemoryUsage(pushAll(toList, []interface{}{value}, dstEnd == interfaces.ListLeft))
+		if dst != src {
+			to.notifyWatchers(dst, toList)
+		}
+	}
+
+	// 写入dst可能触发淘汰，src已被淘汰时不再处理
+	if cur, exists := from.data[src]; exists && cur == fromList {
+		if fromList.Len() == 0 {
+			from.stats.updateMemoryUsage(-int64(fromList.Size()))
+			from.removeLocked(src, fromList)
+		} else {
+			from.notifyWatchers(src, fromList)
+		}
+	}
+	return value, nil
+}
+
+// liveListLocked 返回未过期的列表，键不存在或已过期时返回nil，不是列表时返回ErrTypeMismatch
+func (e *StorageEngine) liveListLocked(key string) (interfaces.ListObject, error) {
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return nil, nil
+	}
+	list, ok := obj.(interfaces.ListObject)
+	if !ok {
+		return nil, errors.ErrTypeMismatch
+	}
+	return list, nil
+}
+
+// validListEnd 检查是否为ListLeft或ListRight
+func validListEnd(end interfaces.ListEnd) bool {
+	return end == interfaces.ListLeft || end == interfaces.ListRight
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+)
+
+// modifiedTracker 支持查询和设置最后修改时间的对象（写入及HSet、IncrBy、Push等原地修改均会更新）
+type modifiedTracker interface {
+	ModifiedAt() time.Time
+	SetModifiedAt(t time.Time)
+}
+
+// liveObjects 返回引擎中所有未过期的对象
+// 对StorageEngine直接在读锁内复制（分片引擎逐个分片复制），其他实现通过Keys/Get读取
+func liveObjects(engine interfaces.StorageEngine) map[string]interfaces.DataObject {
+	if s, ok := engine.(*ShardedEngine); ok {
+		objects := make(map[string]interfaces.DataObject)
+		for _, shard := range s.shards {
+			for key, obj := range liveObjects(shard) {
+				objects[key] = obj
+			}
+		}
+		return objects
+	}
+	if e, ok := engine.(*StorageEngine); ok {
+		e.mu.RLock()
+		defer e.mu.RUnlock()
+
+		objects := make(map[string]interfaces.DataObject, len(e.data))
+		for key, obj := range e.data {
+			if !e.isExpired(obj) {
+				objects[key] = obj
+			}
+		}
+		return objects
+	}
+
+	keys := engine.Keys()
+	objects := make(map[string]interfaces.DataObject, len(keys))
+	for _, key := range keys {
+		if obj, exists := engine.Get(key); exists {
+			objects[key] = obj
+		}
+	}
+	return objects
+}
+
+// sameValue 比较两个对象的类型和序列化后的值（不比较过期时间）
+func sameValue(key string, a, b interfaces.DataObject) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	ra, errA := NewRecord(key, a)
+	rb, errB := NewRecord(key, b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(ra.Value, rb.Value)
+}
+
+// Diff 比较当前引擎与other的未过期数据，返回other相对当前引擎新增、值不同和缺少的键（均已排序）
+func (e *StorageEngine) Diff(other interfaces.StorageEngine) (added, changed, removed []string) {
+	return diffEngines(e, other)
+}
+
+// diffEngines 返回other相对engine新增、值不同和缺少的键（均已排序）
+func diffEngines(engine, other interfaces.StorageEngine) (added, changed, removed []string) {
+	mine := liveObjects(engine)
+	theirs := liveObjects(other)
+
+	for key, obj := range theirs {
+		existing, ok := mine[key]
+		switch {
+		case !ok:
+			added = append(added, key)
+		case !sameValue(key, existing, obj):
+			changed = append(changed, key)
+		}
+	}
+	for key := range mine {
+		if _, ok := theirs[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return added, changed, removed
+}
+
+// Merge 将other的未过期数据合并到当前引擎，返回写入的键数
+// 值经导出记录复制，两个引擎不共享对象，复制的对象保留来源的最后修改时间；冲突按strategy处理，值相同的键不重复写入
+// 每个键的冲突判断与写入在同一次加锁内完成，合并逐键进行，整体不是原子操作
+func (e *StorageEngine) Merge(other interfaces.StorageEngine, strategy interfaces.MergeStrategy) (int, error) {
+	if e.closed.Load() {
+		return 0, errors.ErrCacheClosed
+	}
+	return mergeFrom(other, strategy, func(key string) (*StorageEngine, string) { return e, key })
+}
+
+// mergeFrom 将other的未过期数据逐键合并到route返回的引擎，route同时返回写入该引擎时使用的键
+func mergeFrom(other interfaces.StorageEngine, strategy interfaces.MergeStrategy, route func(key string) (*StorageEngine, string)) (int, error) {
+	merged := 0
+	for key, obj := range liveObjects(other) {
+		e, target := route(key)
+		record, err := NewRecord(key, obj)
+		if err != nil {
+			return merged, err
+		}
+		copied, alive, err := record.ObjectAt(e.now())
+		if err != nil {
+			return merged, err
+		}
+		if !alive {
+			continue
+		}
+		if src, ok := obj.(modifiedTracker); ok {
+			if dst, ok := copied.(modifiedTracker); ok {
+				dst.SetModifiedAt(src.ModifiedAt())
+			}
+		}
+
+		written, err := e.mergeOne(target, obj, copied, strategy)
+		if err != nil {
+			return merged, err
+		}
+		if written {
+			merged++
+		}
+	}
+	return merged, nil
+}
+
+// mergeOne 在一次加锁内判断冲突并写入copied，避免判断与写入之间被并发写入覆盖
+func (e *StorageEngine) mergeOne(key string, incoming, copied interfaces.DataObject, strategy interfaces.MergeStrategy) (bool, error) {
+	key, err := e.prepareSet(key, copied)
+	if err != nil {
+		return false, err
+	}
+	if err := e.checkMemory(); err != nil {
+		return false, err
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	existing, exists := e.data[key]
+	if exists && !e.isExpired(existing) && (sameValue(key, existing, incoming) || !e.mergeWins(existing, incoming, strategy)) {
+		return false, nil
+	}
+	if err := e.setLocked(key, copied, &notices); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mergeWins 判断冲突时来源对象是否覆盖已有对象
+func (e *StorageEngine) mergeWins(existing, incoming interfaces.DataObject, strategy interfaces.MergeStrategy) bool {
+	switch strategy {
+	case interfaces.MergeKeepExisting:
+		return false
+	case interfaces.MergeNewestWins:
+		a, okA := existing.(modifiedTracker)
+		b, okB := incoming.(modifiedTracker)
+		if !okA || !okB {
+			return true
+		}
+		return b.ModifiedAt().After(a.ModifiedAt())
+	default:
+		return true
+	}
+}
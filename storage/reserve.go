@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/utils"
+)
+
+// Reserve 为键预留一个容量槽位，预留期间键对读取不可见且不会被淘汰
+// 已有的值会被删除；之后对该键的Set消耗预留，CancelReservation释放预留
+func (e *StorageEngine) Reserve(key string) error {
+	if e.closed.Load() {
+		return errors.ErrCacheClosed
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return err
+	}
+
+	// OnEvict回调在释放锁之后执行
+	var evictedKey string
+	var evictedObj interfaces.DataObject
+	defer func() {
+		e.notifyEvict(evictedKey, evictedObj)
+	}()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.reserved[key]; exists {
+		return nil
+	}
+
+	if obj, exists := e.data[key]; exists {
+		e.stats.updateMemoryUsage(-int64(obj.Size()))
+		delete(e.data, key)
+		e.policy.Delete(key)
+		e.indexRemove(key)
+		e.closeWatchers(key)
+	} else if e.config.MaxSize > 0 && len(e.data)+len(e.reserved) >= e.config.MaxSize {
+		if e.config.BackgroundCleanupInterval == 0 {
+			return fmt.Errorf("storage capacity exceeded: max size %d reached", e.config.MaxSize)
+		}
+		evictedKey, evictedObj = e.evictOne()
+	}
+
+	if e.reserved == nil {
+		e.reserved = make(map[string]struct{})
+	}
+	e.reserved[key] = struct{}{}
+	return nil
+}
+
+// CancelReservation 释放尚未写入的预留，键未预留时返回false
+func (e *StorageEngine) CancelReservation(key string) bool {
+	if e.closed.Load() {
+		return false
+	}
+	key = e.normalizeKey(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.reserved[key]; !exists {
+		return false
+	}
+	delete(e.reserved, key)
+	return true
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"path"
+	"sort"
+)
+
+// scanPruneSlack 扫描日志中的失效条目超过存活键数加该值时才压缩，避免频繁重建
+const scanPruneSlack = 64
+
+// defaultScanCount Scan未指定count时每次检查的键数
+const defaultScanCount = 10
+
+// scanEntry 扫描日志条目，seq为键写入时分配的单调递增序号
+type scanEntry struct {
+	seq int
+	key string
+}
+
+// scanIndex 按插入顺序记录键，为Scan提供稳定的游标
+// 键被删除后条目延迟清理：Scan跳过键已不存在或序号已变化的条目，写入新键时按需压缩
+type scanIndex struct {
+	next    int            // 上一次分配的序号
+	seqs    map[string]int // 键当前的序号
+	entries []scanEntry    // 按seq升序
+}
+
+// scanAdd 为新写入的键分配序号，必须在持有写锁且键尚不存在时调用
+func (e *StorageEngine) scanAdd(key string) {
+	s := &e.scan
+	if s.seqs == nil {
+		s.seqs = make(map[string]int)
+	}
+	if len(s.entries) >= 2*len(e.data)+scanPruneSlack {
+		e.scanPrune()
+	}
+	s.next++
+	s.seqs[key] = s.next
+	s.entries = append(s.entries, scanEntry{seq: s.next, key: key})
+}
+
+// scanPrune 删除已失效的条目，必须在持有写锁的情况下调用
+func (e *StorageEngine) scanPrune() {
+	s := &e.scan
+	live := s.entries[:0]
+	for _, entry := range s.entries {
+		if e.scanLive(entry) {
+			live = append(live, entry)
+		} else if _, exists := e.data[entry.key]; !exists {
+			delete(s.seqs, entry.key)
+		}
+	}
+	clear(s.entries[len(live):])
+	s.entries = live
+}
+
+// scanLive 条目对应的键仍存在且未被删除后重新写入
+func (e *StorageEngine) scanLive(entry scanEntry) bool {
+	if _, exists := e.data[entry.key]; !exists {
+		return false
+	}
+	return e.scan.seqs[entry.key] == entry.seq
+}
+
+// scanReset 清空扫描日志，序号继续递增，Flush之前的游标不会跳过之后写入的键；必须在持有写锁的情况下调用
+func (e *StorageEngine) scanReset() {
+	e.scan = scanIndex{next: e.scan.next}
+}
+
+// Scan 从cursor之后按写入顺序检查最多count个键，返回其中匹配match（path.Match语法，空表示全部）的未过期键和下一次的游标
+// cursor为0表示从头开始，返回的游标为0表示遍历结束；count小于等于0时为10，match格式错误时返回0和nil
+// 整个遍历期间一直存在的键恰好返回一次；遍历期间新写入的键可能返回也可能不返回，删除后重新写入的键可能再次返回
+func (e *StorageEngine) Scan(cursor int, match string, count int) (int, []string) {
+	if e.closed.Load() {
+		return 0, nil
+	}
+	if match == "" {
+		match = "*"
+	}
+	if _, err := path.Match(match, ""); err != nil {
+		return 0, nil
+	}
+	if count <= 0 {
+		count = defaultScanCount
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	entries := e.scan.entries
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].seq > cursor })
+	keys := make([]string, 0)
+	for examined := 0; i < len(entries) && examined < count; i++ {
+		entry := entries[i]
+		if !e.scanLive(entry) {
+			continue
+		}
+		examined++
+		if matched, _ := path.Match(match, entry.key); matched && !e.isExpired(e.data[entry.key]) {
+			keys = append(keys, entry.key)
+		}
+	}
+
+	if i >= len(entries) {
+		return 0, keys
+	}
+	return entries[i-1].seq, keys
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// SAdd 向Set添加成员，返回新增的成员数；键不存在或已过期时创建永不过期的Set
+// 未提供成员或成员不可比较（切片、map等）时返回ErrInvalidArgument且不做任何修改
+func (e *StorageEngine) SAdd(key string, members ...interface{}) (int, error) {
+	if e.closed.Load() {
+		return 0, errors.ErrCacheClosed
+	}
+	key = e.normalizeKey(key)
+
+	if err := utils.ValidateCacheKey(key); err != nil {
+		return 0, err
+	}
+	if len(members) == 0 {
+		return 0, errors.ErrInvalidArgument
+	}
+	for _, m := range members {
+		if !types.IsSetMember(m) {
+			return 0, errors.ErrInvalidArgument
+		}
+	}
+
+	var notices setNotices
+	defer e.fireNotices(&notices)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		set := types.NewSetObject(members, 0)
+		added := set.Len()
+		if err := e.setLocked(key, set, &notices); err != nil {
+			return 0, err
+		}
+		return added, nil
+	}
+
+	set, ok := obj.(*types.SetObject)
+	if !ok {
+		return 0, errors.ErrTypeMismatch
+	}
+	// 按新增的成员计算内存变化，不遍历整个Set
+	added, delta := 0, 0
+	for _, m := range members {
+		n := set.Len()
+		if set.Add(m) {
+			added++
+			delta += types.ElementSize(m, n)
+		}
+	}
+	if added > 0 {
+		e.stats.updateMemoryUsage(int64(delta))
+		e.notifyWatchers(key, set)
+	}
+	return added, nil
+}
+
+// SRem 从Set移除成员，返回实际移除的成员数；最后一个成员被移除时删除整个键
+// 键不存在或已过期时返回0，键不是Set时返回ErrTypeMismatch
+func (e *StorageEngine) SRem(key string, members ...interface{}) (int, error) {
+	if e.closed.Load() {
+		return 0, errors.ErrCacheClosed
+	}
+	key = e.normalizeKey(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	obj, exists := e.data[key]
+	if !exists || e.isExpired(obj) {
+		return 0, nil
+	}
+	set, ok := obj.(*types.SetObject)
+	if !ok {
+		return 0, errors.ErrTypeMismatch
+	}
+
+	removed, delta := 0, 0
+	for _, m := range members {
+		if set.Remove(m) {
+			removed++
+			delta -= types.ElementSize(m, set.Len())
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	e.stats.updateMemoryUsage(int64(delta))
+	if set.Len() == 0 {
+		e.stats.updateMemoryUsage(-int64(set.Size()))
+		e.removeLocked(key, set)
+		return removed, nil
+	}
+	e.notifyWatchers(key, set)
+	return removed, nil
+}
+
+// removeLocked 删除键并维护策略、索引与监听者，内存统计由调用者更新，必须在持有写锁的情况下调用
+func (e *StorageEngine) removeLocked(key string, obj interfaces.DataObject) {
+	delete(e.data, key)
+	e.policy.Delete(key)
+	e.indexRemove(key)
+	e.closeWatchers(key)
+	e.stats.recordDelete()
+	e.afterRemove()
+}
@@ -0,0 +1,787 @@
+package storage
+
+import (
+	"bufio"
+	"hash/fnv"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// ShardedEngine 按键的FNV哈希分片的存储引擎，每个分片是独立加锁、独立淘汰的StorageEngine
+// 单键操作只锁定键所在的分片；Size/Keys/Flush/Stats等汇总所有分片
+// 与单锁引擎的差异：MaxSize/MaxMemoryBytes按分片均分，淘汰只在分片内按策略进行；
+// MSet、DeleteMany、MGetTouch、MTTL、Flush、Export等跨分片的操作逐个分片执行，整体不是原子的；
+// 跨分片的RenameIf/LMove同时锁定两个分片，仍是原子的；OnFull/OnSoftLimit按分片触发，参数为分片的键数和容量
+type ShardedEngine struct {
+	shards []*StorageEngine
+	mask   uint32
+	config *config.EngineConfig
+}
+
+// newShardedEngine 按已校验的配置创建engineConfig.Shards个分片
+func newShardedEngine(engineConfig *config.EngineConfig) *ShardedEngine {
+	n := engineConfig.Shards
+
+	// 分片共享同一份配置副本：键在路由前已规范化，容量按分片均分
+	// 访问日志不均分，每个分片保留最近AccessLogSize次读取，热点集中的分片不会丢失记录
+	shardConfig := *engineConfig
+	shardConfig.Shards = 1
+	shardConfig.KeyNormalizer = nil
+	shardConfig.MaxSize = ceilDiv(engineConfig.MaxSize, n)
+	shardConfig.MaxMemoryBytes = ceilDiv(engineConfig.MaxMemoryBytes, int64(n))
+
+	s := &ShardedEngine{
+		shards: make([]*StorageEngine, n),
+		mask:   uint32(n - 1),
+		config: engineConfig,
+	}
+	for i := range s.shards {
+		s.shards[i] = newStorageEngine(&shardConfig)
+	}
+	return s
+}
+
+// ceilDiv 向上取整的除法，v不大于0时原样返回
+func ceilDiv[T int | int64](v, n T) T {
+	if v <= 0 {
+		return v
+	}
+	return (v + n - 1) / n
+}
+
+// normalizeKey 按配置的KeyNormalizer规范化键
+func (s *ShardedEngine) normalizeKey(key string) string {
+	if s.config.KeyNormalizer == nil {
+		return key
+	}
+	return s.config.KeyNormalizer(key)
+}
+
+// index 返回规范化后的键所在的分片序号
+func (s *ShardedEngine) index(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() & s.mask)
+}
+
+// route 返回键所在的分片和规范化后的键
+func (s *ShardedEngine) route(key string) (*StorageEngine, string) {
+	key = s.normalizeKey(key)
+	return s.shards[s.index(key)], key
+}
+
+// lockPair 按分片序号顺序获取两个不同分片的写锁，避免与其他跨分片操作死锁，返回解锁函数
+func (s *ShardedEngine) lockPair(i, j int) func() {
+	if i > j {
+		i, j = j, i
+	}
+	s.shards[i].mu.Lock()
+	s.shards[j].mu.Lock()
+	return func() {
+		s.shards[j].mu.Unlock()
+		s.shards[i].mu.Unlock()
+	}
+}
+
+// Set 存储对象
+func (s *ShardedEngine) Set(key string, obj interfaces.DataObject) error {
+	shard, key := s.route(key)
+	return shard.Set(key, obj)
+}
+
+// MSet 按分片分组写入多个对象，键的校验在写入前完成，任一键无效时不写入任何键
+// 每个分片在一次加锁内写入，分片之间不是原子的；出错时停止，已写入的键保留
+// 键数超过MaxBatchSize时按BatchOverflow整批拒绝或在分片内分块写入，返回的*errors.BatchError列出未写入的键
+func (s *ShardedEngine) MSet(objs map[string]interfaces.DataObject) error {
+	if s.Closed() {
+		return errors.ErrCacheClosed
+	}
+
+	limit := s.config.MaxBatchSize
+	oversized := limit > 0 && len(objs) > limit
+	if oversized && s.config.BatchOverflow == config.BatchReject {
+		rejected := make([]string, 0, len(objs))
+		for key := range objs {
+			rejected = append(rejected, key)
+		}
+		slices.Sort(rejected)
+		return &errors.BatchError{Rejected: rejected, Err: errors.ErrBatchTooLarge}
+	}
+
+	groups := make([]map[string]interfaces.DataObject, len(s.shards))
+	for key, obj := range objs {
+		shard, key := s.route(key)
+		key, err := shard.prepareSet(key, obj)
+		if err != nil {
+			return err
+		}
+		i := s.index(key)
+		if groups[i] == nil {
+			groups[i] = make(map[string]interfaces.DataObject)
+		}
+		groups[i][key] = obj
+	}
+	if err := s.shards[0].checkMemory(); err != nil {
+		return err
+	}
+
+	keys := make([][]string, len(s.shards))
+	for i, group := range groups {
+		for key := range group {
+			keys[i] = append(keys[i], key)
+		}
+		if oversized || s.config.DeterministicEviction {
+			slices.Sort(keys[i]) // 固定写入顺序，淘汰顺序不依赖map遍历顺序
+		}
+	}
+
+	for i, group := range groups {
+		step := len(keys[i])
+		if oversized {
+			step = limit
+		}
+		for start := 0; start < len(keys[i]); start += step {
+			end := min(start+step, len(keys[i]))
+			written, err := s.shards[i].msetChunk(keys[i][start:end], group)
+			if err == nil {
+				continue
+			}
+			if !oversized {
+				return err
+			}
+			// 失败的键、该分片之后的键以及后续分片的键均未写入
+			rejected := slices.Clone(keys[i][start+written:])
+			for _, rest := range keys[i+1:] {
+				rejected = append(rejected, rest...)
+			}
+			return &errors.BatchError{Rejected: rejected, Err: err}
+		}
+	}
+	return nil
+}
+
+// SetNX 仅当键不存在或已过期时写入
+func (s *ShardedEngine) SetNX(key string, obj interfaces.DataObject) (bool, error) {
+	shard, key := s.route(key)
+	return shard.SetNX(key, obj)
+}
+
+// SetWithOptions 按写入条件存储对象
+func (s *ShardedEngine) SetWithOptions(key string, obj interfaces.DataObject, opts interfaces.SetOptions) (bool, error) {
+	shard, key := s.route(key)
+	return shard.SetWithOptions(key, obj, opts)
+}
+
+// Get Get object
+func (s *ShardedEngine) Get(key string) (interfaces.DataObject, bool) {
+	shard, key := s.route(key)
+	return shard.Get(key)
+}
+
+// Delete Delete object
+func (s *ShardedEngine) Delete(key string) bool {
+	shard, key := s.route(key)
+	return shard.Delete(key)
+}
+
+// DeleteMany 按分片分组删除多个键，返回删除的键数和每个键是否被删除
+// 结果map以调用方传入的键为索引，规范化后相同的键只有第一个计为删除
+func (s *ShardedEngine) DeleteMany(keys []string) (int, map[string]bool) {
+	results := make(map[string]bool, len(keys))
+	if s.Closed() {
+		return 0, results
+	}
+
+	owners := make(map[string]string, len(keys)) // 规范化后的键 -> 第一个对应的调用方键
+	groups := make([][]string, len(s.shards))
+	for _, key := range keys {
+		if _, seen := results[key]; seen {
+			continue
+		}
+		results[key] = false
+		normalized := s.normalizeKey(key)
+		if _, claimed := owners[normalized]; claimed {
+			continue
+		}
+		owners[normalized] = key
+		i := s.index(normalized)
+		groups[i] = append(groups[i], normalized)
+	}
+
+	removed := 0
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		n, deleted := s.shards[i].DeleteMany(group)
+		removed += n
+		for normalized, ok := range deleted {
+			if ok {
+				results[owners[normalized]] = true
+			}
+		}
+	}
+	return removed, results
+}
+
+// GetAndDelete 在键所在分片的一次加锁内读取并删除对象
+func (s *ShardedEngine) GetAndDelete(key string, dataTypes ...interfaces.DataType) (interfaces.DataObject, bool) {
+	shard, key := s.route(key)
+	return shard.GetAndDelete(key, dataTypes...)
+}
+
+// MGetTouch 按分片分组批量读取并提升键的访问顺序，只返回存在且未过期的键（以调用方传入的键为索引）
+func (s *ShardedEngine) MGetTouch(keys []string) map[string]interfaces.DataObject {
+	result := make(map[string]interfaces.DataObject, len(keys))
+	if s.Closed() {
+		return result
+	}
+
+	groups := make([][]string, len(s.shards))
+	callers := make([][]string, len(s.shards))
+	for _, requested := range keys {
+		key := s.normalizeKey(requested)
+		i := s.index(key)
+		groups[i] = append(groups[i], key)
+		callers[i] = append(callers[i], requested)
+	}
+
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		found := s.shards[i].MGetTouch(group)
+		for j, key := range group {
+			if obj, ok := found[key]; ok {
+				result[callers[i][j]] = obj
+			}
+		}
+	}
+	return result
+}
+
+// RenameIf 当src的当前字符串值等于expected时将其重命名为dst，src与dst位于不同分片时同时锁定两个分片
+func (s *ShardedEngine) RenameIf(src, dst, expected string) bool {
+	src, dst = s.normalizeKey(src), s.normalizeKey(dst)
+	from, to := s.index(src), s.index(dst)
+	if from == to {
+		return s.shards[from].RenameIf(src, dst, expected)
+	}
+	if s.Closed() || src == "" || dst == "" {
+		return false
+	}
+
+	unlock := s.lockPair(from, to)
+	defer unlock()
+
+	return renameAcrossLocked(s.shards[from], s.shards[to], src, dst, expected)
+}
+
+// renameAcrossLocked 将from中的src移动到to中的dst，调用方持有两个分片的写锁
+// 与StorageEngine.RenameIf相同，对象直接移动，不经过准入过滤、容量检查和写入统计
+func renameAcrossLocked(from, to *StorageEngine, src, dst, expected string) bool {
+	obj, exists := from.data[src]
+	if !exists || from.isExpired(obj) {
+		return false
+	}
+	str, ok := obj.(*types.StringObject)
+	if !ok || str.Value() != expected {
+		return false
+	}
+
+	size := int64(obj.Size())
+	from.stats.updateMemoryUsage(-size)
+	delete(from.data, src)
+	from.policy.Delete(src)
+	from.indexRemove(src)
+	from.closeWatchers(src)
+	from.afterRemove()
+
+	if old, exists := to.data[dst]; exists {
+		to.stats.updateMemoryUsage(-int64(old.Size()))
+		to.policy.Delete(dst)
+	} else {
+		to.scanAdd(dst)
+	}
+	to.stats.updateMemoryUsage(size)
+	to.data[dst] = obj
+	delete(to.reserved, dst)
+	to.policy.Set(dst)
+	to.trackExpiry(dst, obj)
+	to.indexSet(dst, obj)
+	to.notifyWatchers(dst, obj)
+	return true
+}
+
+// Exists 检查键是否存在
+func (s *ShardedEngine) Exists(key string) bool {
+	shard, key := s.route(key)
+	return shard.Exists(key)
+}
+
+// Keys Get all keys
+func (s *ShardedEngine) Keys() []string {
+	if s.Closed() {
+		return nil
+	}
+	keys := make([]string, 0)
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// KeysByType 返回指定类型的所有未过期键
+func (s *ShardedEngine) KeysByType(dt interfaces.DataType) []string {
+	if s.Closed() {
+		return nil
+	}
+	keys := make([]string, 0)
+	for _, shard := range s.shards {
+		keys = append(keys, shard.KeysByType(dt)...)
+	}
+	return keys
+}
+
+// KeysMatch 返回匹配glob模式的未过期键（已排序），空模式等同于*，模式格式错误时返回nil
+func (s *ShardedEngine) KeysMatch(pattern string) []string {
+	if s.Closed() {
+		return nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil
+	}
+	keys := make([]string, 0)
+	for _, shard := range s.shards {
+		keys = append(keys, shard.KeysMatch(pattern)...)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// KeysPage 按键排序分页返回（page从1开始）
+func (s *ShardedEngine) KeysPage(page, pageSize int) types.KeyPage {
+	return types.PageKeys(s.Keys(), page, pageSize)
+}
+
+// scanShardShift 分片引擎的Scan游标中分片内游标占用的低位数，高位为分片序号，游标随遍历单调递增
+const scanShardShift = 48
+
+// Scan 按分片序号依次遍历各分片，每次调用只在一个分片内检查最多count个键
+// 返回的游标为0表示所有分片遍历结束；一次调用可能返回空结果和非0游标，其余保证与StorageEngine.Scan相同
+func (s *ShardedEngine) Scan(cursor int, match string, count int) (int, []string) {
+	if s.Closed() || cursor < 0 {
+		return 0, nil
+	}
+	if _, err := path.Match(match, ""); err != nil {
+		return 0, nil
+	}
+
+	i, inner := cursor>>scanShardShift, cursor&(1<<scanShardShift-1)
+	if i >= len(s.shards) {
+		return 0, nil
+	}
+	next, keys := s.shards[i].Scan(inner, match, count)
+	if next != 0 {
+		return i<<scanShardShift | next, keys
+	}
+	if i+1 < len(s.shards) {
+		return (i + 1) << scanShardShift, keys
+	}
+	return 0, keys
+}
+
+// Flush 逐个清空所有分片，分片之间不是原子的
+func (s *ShardedEngine) Flush() error {
+	for _, shard := range s.shards {
+		if err := shard.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size 返回所有分片的键数之和
+func (s *ShardedEngine) Size() int {
+	size := 0
+	for _, shard := range s.shards {
+		size += shard.Size()
+	}
+	return size
+}
+
+// Type Get key type
+func (s *ShardedEngine) Type(key string) (interfaces.DataType, bool) {
+	shard, key := s.route(key)
+	return shard.Type(key)
+}
+
+// Expire 设置过期时间
+func (s *ShardedEngine) Expire(key string, ttl time.Duration) bool {
+	shard, key := s.route(key)
+	return shard.Expire(key, ttl)
+}
+
+// TTL Get remaining TTL
+func (s *ShardedEngine) TTL(key string) (time.Duration, bool) {
+	shard, key := s.route(key)
+	return shard.TTL(key)
+}
+
+// ExpireTime 返回键的绝对过期时间
+func (s *ShardedEngine) ExpireTime(key string) (time.Time, bool) {
+	shard, key := s.route(key)
+	return shard.ExpireTime(key)
+}
+
+// MTTL 按位置返回多个键的剩余生存时间（秒），每个分片内一次加锁，分片之间不是原子的
+func (s *ShardedEngine) MTTL(keys ...string) []int {
+	result := make([]int, len(keys))
+	groups := make([][]string, len(s.shards))
+	positions := make([][]int, len(s.shards))
+	for pos, key := range keys {
+		key = s.normalizeKey(key)
+		i := s.index(key)
+		groups[i] = append(groups[i], key)
+		positions[i] = append(positions[i], pos)
+	}
+
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		for j, ttl := range s.shards[i].MTTL(group...) {
+			result[positions[i][j]] = ttl
+		}
+	}
+	return result
+}
+
+// ExpireMatching 为所有分片中匹配glob模式的未过期键设置新的TTL，返回更新的键数
+func (s *ShardedEngine) ExpireMatching(pattern string, ttl time.Duration) int {
+	updated := 0
+	for _, shard := range s.shards {
+		updated += shard.ExpireMatching(pattern, ttl)
+	}
+	return updated
+}
+
+// IncrBy 原子地对整数字符串值加上delta
+func (s *ShardedEngine) IncrBy(key string, delta int64) (int64, error) {
+	shard, key := s.route(key)
+	return shard.IncrBy(key, delta)
+}
+
+// HSet 设置Hash字段
+func (s *ShardedEngine) HSet(key, field string, value interface{}) error {
+	shard, key := s.route(key)
+	return shard.HSet(key, field, value)
+}
+
+// HDel 删除Hash字段
+func (s *ShardedEngine) HDel(key, field string) bool {
+	shard, key := s.route(key)
+	return shard.HDel(key, field)
+}
+
+// HDelMany 删除多个Hash字段，返回删除的字段数
+func (s *ShardedEngine) HDelMany(key string, fields ...string) int {
+	shard, key := s.route(key)
+	return shard.HDelMany(key, fields...)
+}
+
+// CreateIndex 在所有分片上为匹配keyPattern的Hash键的field字段建立二级索引
+func (s *ShardedEngine) CreateIndex(keyPattern, field string) error {
+	for _, shard := range s.shards {
+		if err := shard.CreateIndex(keyPattern, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LookupIndex 返回field字段等于value的所有未过期键（已排序），字段未建立索引时返回nil
+func (s *ShardedEngine) LookupIndex(field string, value interface{}) []string {
+	keys := make([]string, 0)
+	for _, shard := range s.shards {
+		found := shard.LookupIndex(field, value)
+		if found == nil {
+			return nil
+		}
+		keys = append(keys, found...)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// SAdd 向Set添加成员
+func (s *ShardedEngine) SAdd(key string, members ...interface{}) (int, error) {
+	shard, key := s.route(key)
+	return shard.SAdd(key, members...)
+}
+
+// SRem 从Set移除成员
+func (s *ShardedEngine) SRem(key string, members ...interface{}) (int, error) {
+	shard, key := s.route(key)
+	return shard.SRem(key, members...)
+}
+
+// Reserve 在键所在分片预留容量
+func (s *ShardedEngine) Reserve(key string) error {
+	shard, key := s.route(key)
+	return shard.Reserve(key)
+}
+
+// CancelReservation 取消键的预留
+func (s *ShardedEngine) CancelReservation(key string) bool {
+	shard, key := s.route(key)
+	return shard.CancelReservation(key)
+}
+
+// LPush 在列表头部写入
+func (s *ShardedEngine) LPush(key string, values ...interface{}) (int, error) {
+	shard, key := s.route(key)
+	return shard.LPush(key, values...)
+}
+
+// RPush 在列表尾部写入
+func (s *ShardedEngine) RPush(key string, values ...interface{}) (int, error) {
+	shard, key := s.route(key)
+	return shard.RPush(key, values...)
+}
+
+// LPop 移除并返回列表头部元素
+func (s *ShardedEngine) LPop(key string) (interface{}, bool) {
+	shard, key := s.route(key)
+	return shard.LPop(key)
+}
+
+// RPop 移除并返回列表尾部元素
+func (s *ShardedEngine) RPop(key string) (interface{}, bool) {
+	shard, key := s.route(key)
+	return shard.RPop(key)
+}
+
+// LMove 从src取出元素并写入dst，src与dst位于不同分片时同时锁定两个分片，语义与StorageEngine.LMove相同
+func (s *ShardedEngine) LMove(src, dst string, srcEnd, dstEnd interfaces.ListEnd) (interface{}, error) {
+	if s.Closed() {
+		return nil, errors.ErrCacheClosed
+	}
+	if !validListEnd(srcEnd) || !validListEnd(dstEnd) {
+		return nil, errors.ErrInvalidArgument
+	}
+	src, dst = s.normalizeKey(src), s.normalizeKey(dst)
+	from, to := s.index(src), s.index(dst)
+	if from == to {
+		return s.shards[from].LMove(src, dst, srcEnd, dstEnd)
+	}
+	if err := utils.ValidateCacheKey(dst); err != nil {
+		return nil, err
+	}
+
+	var notices setNotices
+	defer s.shards[to].fireNotices(&notices)
+
+	unlock := s.lockPair(from, to)
+	defer unlock()
+
+	return lmoveLocked(s.shards[from], s.shards[to], src, dst, srcEnd, dstEnd, &notices)
+}
+
+// LPushCap 在固定容量环形列表头部写入
+func (s *ShardedEngine) LPushCap(key string, capacity int, value interface{}) error {
+	shard, key := s.route(key)
+	return shard.LPushCap(key, capacity, value)
+}
+
+// RPushCap 在固定容量环形列表尾部写入
+func (s *ShardedEngine) RPushCap(key string, capacity int, value interface{}) error {
+	shard, key := s.route(key)
+	return shard.RPushCap(key, capacity, value)
+}
+
+// Watch 监听键的变化
+func (s *ShardedEngine) Watch(key string) (<-chan interface{}, func()) {
+	shard, key := s.route(key)
+	return shard.Watch(key)
+}
+
+// Stats 汇总所有分片的统计信息，计数器、键数和内存按分片求和，shards为分片数
+func (s *ShardedEngine) Stats() interface{} {
+	result := s.shards[0].Stats().(map[string]interface{})
+
+	var snap statsSnapshot
+	var policy interfaces.PolicyStats
+	var windowHits, windowMisses int64
+	var memoryLimit int64
+	keys, reserved, pending := 0, 0, 0
+	for _, shard := range s.shards {
+		shard.mu.RLock()
+		keys += len(shard.data)
+		reserved += len(shard.reserved)
+		shardPolicy := shard.policy.Stats()
+		shard.mu.RUnlock()
+
+		snap.add(shard.stats.snapshot())
+		policy.Operations += shardPolicy.Operations
+		policy.Evictions += shardPolicy.Evictions
+		if shardPolicy.LastOpTime.After(policy.LastOpTime) {
+			policy.LastOpTime = shardPolicy.LastOpTime
+		}
+		if s.config.StatsWindow > 0 {
+			hits, misses := shard.stats.windowCounts()
+			windowHits += hits
+			windowMisses += misses
+		}
+		memoryLimit += shard.memoryLimit()
+		if s.config.TwoPhaseDelete {
+			pending += shard.pendingSweep()
+		}
+	}
+
+	result["hits"] = snap.hits
+	result["misses"] = snap.misses
+	result["sets"] = snap.sets
+	result["deletes"] = snap.deletes
+	result["evictions"] = snap.evictions
+	result["expirations"] = snap.expirations
+	result["memory"] = snap.memoryUsage
+	result["keys"] = keys
+	result["hit_rate"] = snap.hitRate()
+	result["pool_hits"] = snap.poolHits
+	result["pool_allocs"] = snap.poolAllocs
+	result["admission_rejects"] = snap.rejections
+	result["policy"] = policy
+	result["max_size"] = s.config.MaxSize
+	result["memory_limit"] = memoryLimit
+	result["reserved"] = reserved
+	result["shards"] = len(s.shards)
+	if uptime, ok := result["uptime"].(time.Duration); ok && uptime > 0 {
+		ops := snap.hits + snap.misses + snap.sets + snap.deletes
+		result["ops_per_sec"] = float64(ops) / uptime.Seconds()
+	}
+	if s.config.TwoPhaseDelete {
+		result["pending_sweep"] = pending
+	}
+	if s.config.StatsWindow > 0 {
+		result["window_hit_rate"] = 0.0
+		if total := windowHits + windowMisses; total > 0 {
+			result["window_hit_rate"] = float64(windowHits) / float64(total)
+		}
+	}
+	return result
+}
+
+// add 累加另一个分片的计数器（GC统计是进程级的，保留第一个分片的值）
+func (s *statsSnapshot) add(o statsSnapshot) {
+	s.hits += o.hits
+	s.misses += o.misses
+	s.sets += o.sets
+	s.deletes += o.deletes
+	s.evictions += o.evictions
+	s.expirations += o.expirations
+	s.rejections += o.rejections
+	s.memoryUsage += o.memoryUsage
+	s.poolHits += o.poolHits
+	s.poolAllocs += o.poolAllocs
+	if s.gcCycles == 0 {
+		s.gcCycles = o.gcCycles
+	}
+}
+
+// HotKeys 合并所有分片的访问日志，返回最近window内访问最多的top个键，未启用访问日志时返回nil
+func (s *ShardedEngine) HotKeys(window time.Duration, top int) []types.KeyInfo {
+	if s.config.AccessLogSize == 0 || s.Closed() {
+		return nil
+	}
+	result := make([]types.KeyInfo, 0)
+	for _, shard := range s.shards {
+		result = append(result, shard.HotKeys(window, top)...)
+	}
+	return rankKeyInfos(result, top)
+}
+
+// Diff 比较当前引擎与other的未过期数据，返回other相对当前引擎新增、值不同和缺少的键（均已排序）
+func (s *ShardedEngine) Diff(other interfaces.StorageEngine) (added, changed, removed []string) {
+	return diffEngines(s, other)
+}
+
+// Merge 将other的未过期数据逐键合并到各分片，返回写入的键数，语义与StorageEngine.Merge相同
+func (s *ShardedEngine) Merge(other interfaces.StorageEngine, strategy interfaces.MergeStrategy) (int, error) {
+	if s.Closed() {
+		return 0, errors.ErrCacheClosed
+	}
+	return mergeFrom(other, strategy, s.route)
+}
+
+// Compact 逐个压缩所有分片
+func (s *ShardedEngine) Compact() {
+	for _, shard := range s.shards {
+		shard.Compact()
+	}
+}
+
+// Export 逐个分片流式导出所有未过期数据，格式与StorageEngine.Export相同
+func (s *ShardedEngine) Export(w io.Writer) error {
+	if s.Closed() {
+		return errors.ErrCacheClosed
+	}
+	bw := bufio.NewWriter(w)
+	if err := WriteSnapshotHeader(bw); err != nil {
+		return err
+	}
+	for _, shard := range s.shards {
+		if err := shard.exportRecords(bw); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Import 逐条流式导入数据并按键写入对应分片，跳过已过期记录
+func (s *ShardedEngine) Import(r io.Reader) error {
+	if s.Closed() {
+		return errors.ErrCacheClosed
+	}
+	return importSnapshot(r, s.shards[0].now, s.Set)
+}
+
+// Sweep 清理所有分片中已标记的过期键，返回删除的键数
+func (s *ShardedEngine) Sweep() int {
+	swept := 0
+	for _, shard := range s.shards {
+		swept += shard.Sweep()
+	}
+	return swept
+}
+
+// CleanupInterval 返回第一个分片当前的后台清理间隔，未启用后台清理时返回0
+func (s *ShardedEngine) CleanupInterval() time.Duration {
+	return s.shards[0].CleanupInterval()
+}
+
+// GetConfig 获取引擎配置
+func (s *ShardedEngine) GetConfig() *config.EngineConfig {
+	return s.config
+}
+
+// Close 关闭所有分片，可重复调用
+func (s *ShardedEngine) Close() error {
+	for _, shard := range s.shards {
+		shard.Close()
+	}
+	return nil
+}
+
+// Closed 返回引擎是否已关闭
+func (s *ShardedEngine) Closed() bool {
+	return s.shards[0].Closed()
+}
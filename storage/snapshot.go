@@ -0,0 +1,367 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	scerrors "github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+	"github.com/scache-io/scache/utils"
+)
+
+// 导出格式：4字节魔数 + 长度前缀的版本头，之后每条记录为4字节大端长度前缀 + JSON记录体，逐条流式读写
+// 版本1（旧格式）没有魔数和版本头，直接从第一条记录开始
+const maxRecordSize = 64 << 20 // 单条记录最大64MB，防止损坏数据导致超大分配
+
+// SnapshotVersion 当前导出格式版本
+const SnapshotVersion = "2"
+
+// legacySnapshotVersion 不带版本头的旧格式版本
+const legacySnapshotVersion = "1"
+
+// snapshotMagic 版本头魔数；旧格式首字节为记录长度的高位字节（不超过0x04），不会与魔数冲突
+var snapshotMagic = [4]byte{'S', 'C', 'S', 'N'}
+
+// snapshotHeader 版本头
+type snapshotHeader struct {
+	Version string `json:"version"`
+}
+
+// SnapshotMigration 将一条记录体从某个版本升级到下一个版本
+type SnapshotMigration func(record []byte) ([]byte, error)
+
+var (
+	migrationsMu sync.RWMutex
+	// 版本1与版本2的记录体相同，默认原样升级
+	migrations = map[string]SnapshotMigration{
+		legacySnapshotVersion: func(record []byte) ([]byte, error) { return record, nil },
+	}
+)
+
+// RegisterSnapshotMigration 注册从fromVersion升级到下一个版本的记录迁移函数，Import读取旧版本数据时按版本依次应用
+// 重复注册同一版本会覆盖之前的函数
+func RegisterSnapshotMigration(fromVersion string, fn func([]byte) ([]byte, error)) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migrations[fromVersion] = fn
+}
+
+// migrationChain 返回将version升级到当前版本所需的迁移函数，未知版本或缺少迁移时返回错误
+func migrationChain(version string) ([]SnapshotMigration, error) {
+	current, _ := strconv.Atoi(SnapshotVersion)
+	from, err := strconv.Atoi(version)
+	if err != nil || from < 1 || from > current {
+		return nil, fmt.Errorf("unsupported snapshot version %q (current version %s)", version, SnapshotVersion)
+	}
+
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+
+	chain := make([]SnapshotMigration, 0, current-from)
+	for v := from; v < current; v++ {
+		fn, ok := migrations[strconv.Itoa(v)]
+		if !ok {
+			return nil, fmt.Errorf("no migration registered from snapshot version %d", v)
+		}
+		chain = append(chain, fn)
+	}
+	return chain, nil
+}
+
+// Record 导出记录
+type Record struct {
+	Key       string              `json:"key"`
+	Type      interfaces.DataType `json:"type"`
+	ExpiresAt int64               `json:"expires_at,omitempty"` // UnixNano，0表示永不过期
+	Capacity  int                 `json:"capacity,omitempty"`   // 环形列表容量，0表示普通列表
+	Value     json.RawMessage     `json:"value"`
+}
+
+// NewRecord 将数据对象编码为导出记录
+func NewRecord(key string, obj interfaces.DataObject) (*Record, error) {
+	var value interface{}
+	switch obj.Type() {
+	case interfaces.DataTypeString:
+		value, _ = utils.ExtractStringValue(obj)
+	case interfaces.DataTypeList:
+		value, _ = utils.ExtractListValue(obj)
+	case interfaces.DataTypeHash:
+		value, _ = utils.ExtractHashValue(obj)
+	case interfaces.DataTypeSet:
+		value, _ = utils.ExtractSetValue(obj)
+	default:
+		return nil, fmt.Errorf("unsupported data type for export: %s", obj.Type())
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{Key: key, Type: obj.Type(), Value: raw}
+	if expiresAt := obj.ExpiresAt(); !expiresAt.IsZero() {
+		record.ExpiresAt = expiresAt.UnixNano()
+	}
+	if ring, ok := obj.(*types.CircularListObject); ok {
+		record.Capacity = ring.Capacity()
+	}
+	return record, nil
+}
+
+// Object 将导出记录还原为数据对象，已过期时返回false
+// 注意：列表/哈希中的数值经JSON往返后为float64
+func (r *Record) Object() (interfaces.DataObject, bool, error) {
+	return r.ObjectAt(time.Now())
+}
+
+// ObjectAt 同Object，以now作为当前时间判断是否过期，还原后的对象保持记录中的绝对过期时间
+func (r *Record) ObjectAt(now time.Time) (interfaces.DataObject, bool, error) {
+	var ttl time.Duration
+	if r.ExpiresAt != 0 {
+		ttl = time.Unix(0, r.ExpiresAt).Sub(now)
+		if ttl <= 0 {
+			return nil, false, nil
+		}
+	}
+
+	obj, err := r.newObject(ttl)
+	if err != nil {
+		return nil, false, err
+	}
+	if setter, ok := obj.(expirySetter); ok && r.ExpiresAt != 0 {
+		setter.SetExpiresAtFrom(time.Unix(0, r.ExpiresAt), now)
+	}
+	return obj, true, nil
+}
+
+// newObject 按记录的类型与值创建数据对象
+func (r *Record) newObject(ttl time.Duration) (interfaces.DataObject, error) {
+	switch r.Type {
+	case interfaces.DataTypeString:
+		var value string
+		if err := json.Unmarshal(r.Value, &value); err != nil {
+			return nil, err
+		}
+		return types.NewStringObject(value, ttl), nil
+	case interfaces.DataTypeList:
+		var values []interface{}
+		if err := json.Unmarshal(r.Value, &values); err != nil {
+			return nil, err
+		}
+		if r.Capacity > 0 {
+			return types.NewCircularListObject(r.Capacity, values, ttl), nil
+		}
+		return types.NewListObject(values, ttl), nil
+	case interfaces.DataTypeHash:
+		var fields map[string]interface{}
+		if err := json.Unmarshal(r.Value, &fields); err != nil {
+			return nil, err
+		}
+		return types.NewHashObject(fields, ttl), nil
+	case interfaces.DataTypeSet:
+		var members []interface{}
+		if err := json.Unmarshal(r.Value, &members); err != nil {
+			return nil, err
+		}
+		return types.NewSetObject(members, ttl), nil
+	default:
+		return nil, fmt.Errorf("unsupported data type for import: %s", r.Type)
+	}
+}
+
+// WriteRecord 写入一条长度前缀记录
+func WriteRecord(w io.Writer, record *Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, body)
+}
+
+// writeFrame 写入长度前缀数据
+func writeFrame(w io.Writer, body []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(body)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+// ReadRecord 读取一条长度前缀记录，数据结束时返回io.EOF
+func ReadRecord(r io.Reader) (*Record, error) {
+	body, err := readFrame(r)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecord(body)
+}
+
+// decodeRecord 解码记录体
+func decodeRecord(body []byte) (*Record, error) {
+	var record Record
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("invalid record: %w", err)
+	}
+	return &record, nil
+}
+
+// readFrame 读取长度前缀数据，数据结束时返回io.EOF
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("record too large: %d bytes", size)
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// WriteSnapshotHeader 写入魔数和当前版本头
+func WriteSnapshotHeader(w io.Writer) error {
+	body, err := json.Marshal(snapshotHeader{Version: SnapshotVersion})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(snapshotMagic[:]); err != nil {
+		return err
+	}
+	return writeFrame(w, body)
+}
+
+// readSnapshotVersion 读取版本头，没有魔数时按旧格式处理
+func readSnapshotVersion(br *bufio.Reader) (string, error) {
+	magic, err := br.Peek(len(snapshotMagic))
+	if err != nil || [4]byte(magic) != snapshotMagic {
+		// 数据不足4字节（含空数据）或没有魔数，按旧格式交给记录读取处理
+		return legacySnapshotVersion, nil
+	}
+	br.Discard(len(snapshotMagic))
+
+	body, err := readFrame(br)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", fmt.Errorf("invalid snapshot header: %w", err)
+	}
+
+	var header snapshotHeader
+	if err := json.Unmarshal(body, &header); err != nil {
+		return "", fmt.Errorf("invalid snapshot header: %w", err)
+	}
+	return header.Version, nil
+}
+
+// Export 逐条流式导出所有未过期数据（仅复制键列表，不构建完整快照）
+func (e *StorageEngine) Export(w io.Writer) error {
+	if e.closed.Load() {
+		return scerrors.ErrCacheClosed
+	}
+	bw := bufio.NewWriter(w)
+	if err := WriteSnapshotHeader(bw); err != nil {
+		return err
+	}
+	if err := e.exportRecords(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// exportRecords 逐条写入所有未过期数据的记录（不含快照头）
+func (e *StorageEngine) exportRecords(bw *bufio.Writer) error {
+	for _, key := range e.Keys() {
+		e.mu.RLock()
+		obj, exists := e.data[key]
+		e.mu.RUnlock()
+
+		if !exists || e.isExpired(obj) {
+			continue
+		}
+
+		record, err := NewRecord(key, obj)
+		if err != nil {
+			return err
+		}
+		if err := WriteRecord(bw, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import 逐条流式导入数据，跳过已过期记录
+// 旧版本数据按RegisterSnapshotMigration注册的迁移函数逐条升级，未知版本返回错误
+func (e *StorageEngine) Import(r io.Reader) error {
+	if e.closed.Load() {
+		return scerrors.ErrCacheClosed
+	}
+	return importSnapshot(r, e.now, e.Set)
+}
+
+// importSnapshot 读取快照并按now计算剩余TTL，逐条调用set写入未过期的记录
+func importSnapshot(r io.Reader, now func() time.Time, set func(key string, obj interfaces.DataObject) error) error {
+	br := bufio.NewReader(r)
+
+	version, err := readSnapshotVersion(br)
+	if err != nil {
+		return err
+	}
+	chain, err := migrationChain(version)
+	if err != nil {
+		return err
+	}
+
+	for {
+		body, err := readFrame(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, migrate := range chain {
+			if body, err = migrate(body); err != nil {
+				return fmt.Errorf("migrate snapshot record from version %s: %w", version, err)
+			}
+		}
+
+		record, err := decodeRecord(body)
+		if err != nil {
+			return err
+		}
+
+		obj, alive, err := record.ObjectAt(now())
+		if err != nil {
+			return err
+		}
+		if !alive {
+			continue
+		}
+
+		if err := set(record.Key, obj); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import "github.com/scache-io/scache/interfaces"
+
+// tombstoner 支持原子标记待清理的对象
+type tombstoner interface {
+	MarkTombstone() bool
+	Tombstoned() bool
+}
+
+// markExpired 两阶段删除模式下原子标记过期对象并登记待清理，返回是否已交给清理处理
+// 只需读锁或无锁即可调用，读路径不会因删除过期键而获取写锁；未启用两阶段删除时返回false，由调用方立即删除
+func (e *StorageEngine) markExpired(key string, obj interfaces.DataObject) bool {
+	if !e.config.TwoPhaseDelete || e.config.BackgroundCleanupInterval <= 0 {
+		return false
+	}
+	t, ok := obj.(tombstoner)
+	if !ok {
+		return false
+	}
+
+	if t.MarkTombstone() {
+		e.markMu.Lock()
+		e.marked = append(e.marked, key)
+		e.markMu.Unlock()
+	}
+	return true
+}
+
+// Sweep 删除所有已标记的过期键，返回删除的键数；后台清理每轮开始时会自动执行
+func (e *StorageEngine) Sweep() int {
+	if e.closed.Load() {
+		return 0
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	removed := e.sweepLocked()
+	if removed > 0 {
+		e.afterRemove()
+	}
+	return removed
+}
+
+// sweepLocked 删除已标记的键，标记后被重新写入或延长过期时间的键保留，必须在持有写锁的情况下调用
+func (e *StorageEngine) sweepLocked() int {
+	e.markMu.Lock()
+	keys := e.marked
+	e.marked = nil
+	e.markMu.Unlock()
+
+	removed := 0
+	for _, key := range keys {
+		obj, exists := e.data[key]
+		if !exists || !e.isExpired(obj) {
+			continue
+		}
+		if t, ok := obj.(tombstoner); !ok || !t.Tombstoned() {
+			continue
+		}
+		e.removeExpiredLocked(key, obj)
+		removed++
+	}
+	return removed
+}
+
+// pendingSweep 返回已标记待清理的键数
+func (e *StorageEngine) pendingSweep() int {
+	e.markMu.Lock()
+	defer e.markMu.Unlock()
+	return len(e.marked)
+}
+
+// removeExpiredLocked 删除过期键并计入过期统计，必须在持有写锁的情况下调用
+func (e *StorageEngine) removeExpiredLocked(key string, obj interfaces.DataObject) {
+	e.stats.updateMemoryUsage(-int64(obj.Size()))
+	delete(e.data, key)
+	e.policy.Delete(key)
+	e.indexRemove(key)
+	e.closeWatchers(key)
+	e.stats.recordExpiration()
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/utils"
+)
+
+// watcher 单个键的监听者，通道容量为1，慢消费者只会收到最新的值
+type watcher struct {
+	ch     chan interface{}
+	closed bool // 受引擎写锁保护
+}
+
+// watcherSet 同一个键的监听者集合
+type watcherSet map[*watcher]struct{}
+
+// send 投递新值，通道中未读取的旧值被替换，从不阻塞，必须在持有写锁的情况下调用
+func (w *watcher) send(value interface{}) {
+	select {
+	case <-w.ch:
+	default:
+	}
+	select {
+	case w.ch <- value:
+	default:
+	}
+}
+
+// close 关闭通道，必须在持有写锁的情况下调用
+func (w *watcher) close() {
+	if !w.closed {
+		w.closed = true
+		close(w.ch)
+	}
+}
+
+// Watch 监听键的变化，返回的通道在键被写入或修改时收到新值的副本（字符串、[]interface{}或map[string]interface{}），
+// 在键被删除、淘汰、过期移除或引擎Flush/Close时关闭；调用返回的函数取消监听并关闭通道
+// 过期键在被访问或后台清理时才会移除，未启用后台清理时通道不会因到期立即关闭
+func (e *StorageEngine) Watch(key string) (<-chan interface{}, func()) {
+	w := &watcher{ch: make(chan interface{}, 1)}
+	key = e.normalizeKey(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed.Load() {
+		w.close()
+		return w.ch, func() {}
+	}
+	if e.watchers == nil {
+		e.watchers = make(map[string]watcherSet)
+	}
+	if e.watchers[key] == nil {
+		e.watchers[key] = make(watcherSet)
+	}
+	e.watchers[key][w] = struct{}{}
+
+	cancel := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if set := e.watchers[key]; set != nil {
+			delete(set, w)
+			if len(set) == 0 {
+				delete(e.watchers, key)
+			}
+		}
+		w.close()
+	}
+	return w.ch, cancel
+}
+
+// notifyWatchers 向键的监听者投递新值，必须在持有写锁的情况下调用
+func (e *StorageEngine) notifyWatchers(key string, obj interfaces.DataObject) {
+	set := e.watchers[key]
+	if len(set) == 0 {
+		return
+	}
+
+	value := watchValue(obj)
+	for w := range set {
+		w.send(value)
+	}
+}
+
+// closeWatchers 关闭并移除键的所有监听者，必须在持有写锁的情况下调用
+func (e *StorageEngine) closeWatchers(key string) {
+	for w := range e.watchers[key] {
+		w.close()
+	}
+	delete(e.watchers, key)
+}
+
+// closeAllWatchers 关闭所有监听者，必须在持有写锁的情况下调用
+func (e *StorageEngine) closeAllWatchers() {
+	for key := range e.watchers {
+		e.closeWatchers(key)
+	}
+}
+
+// watchValue 复制对象的值，避免监听者持有之后可能被修改或放回对象池的对象
+func watchValue(obj interfaces.DataObject) interface{} {
+	switch obj.Type() {
+	case interfaces.DataTypeList:
+		values, _ := utils.ExtractListValue(obj)
+		return values
+	case interfaces.DataTypeHash:
+		fields, _ := utils.ExtractHashValue(obj)
+		return fields
+	case interfaces.DataTypeSet:
+		members, _ := utils.ExtractSetValue(obj)
+		return members
+	default:
+		value, _ := utils.ExtractStringValue(obj)
+		return value
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+// seenBefore 只准入第二次及以后写入的键，模拟布隆过滤器的"见过"判断
+type seenBefore struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (s *seenBefore) admit(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[key] {
+		return true
+	}
+	s.seen[key] = true
+	return false
+}
+
+func TestAdmissionFilterSkipsFirstWrite(t *testing.T) {
+	filter := &seenBefore{seen: make(map[string]bool)}
+	cfg := config.DefaultEngineConfig()
+	cfg.AdmissionFilter = filter.admit
+	cache := scache.New(cfg)
+	defer cache.Close()
+
+	if err := cache.SetString("k", "v1"); err != nil {
+		t.Fatalf("Rejected write should still succeed, got %v", err)
+	}
+	if cache.Exists("k") {
+		t.Fatal("First write should be skipped by the admission filter")
+	}
+
+	if err := cache.SetString("k", "v2"); err != nil {
+		t.Fatalf("SetString failed: %v", err)
+	}
+	if got, ok := cache.GetString("k"); !ok || got != "v2" {
+		t.Fatalf("Second write should be stored, got %q, %v", got, ok)
+	}
+
+	stats := cache.Stats().(map[string]interface{})
+	if got := stats["admission_rejects"]; got != int64(1) {
+		t.Errorf("Expected 1 admission reject, got %v", got)
+	}
+}
+
+func TestAdmissionFilterAlwaysUpdatesCachedKeys(t *testing.T) {
+	admit := true
+	cfg := config.DefaultEngineConfig()
+	cfg.AdmissionFilter = func(string) bool { return admit }
+	cache := scache.New(cfg)
+	defer cache.Close()
+
+	cache.SetString("k", "old")
+	admit = false
+
+	// 已缓存的键不受过滤影响，否则会留下旧值
+	cache.SetString("k", "new")
+	if got, _ := cache.GetString("k"); got != "new" {
+		t.Errorf("Expected update of cached key, got %q", got)
+	}
+	cache.SetString("other", "v")
+	if cache.Exists("other") {
+		t.Error("New key should be rejected")
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 	"github.com/scache-io/scache"
 	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
 )
 
 // ==================== Basic Operations Benchmarks ====================
@@ -49,6 +52,35 @@ func BenchmarkDeleteString(b *testing.B) {
 	}
 }
 
+// ==================== Batch Read Benchmarks ====================
+
+func populateBatchEngine(b *testing.B) (interfaces.StorageEngine, []string) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	keys := make([]string, 32)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		engine.Set(keys[i], types.NewStringObject("value", 0))
+	}
+	b.ResetTimer()
+	return engine, keys
+}
+
+func BenchmarkMGetTouch(b *testing.B) {
+	engine, keys := populateBatchEngine(b)
+	for i := 0; i < b.N; i++ {
+		engine.MGetTouch(keys)
+	}
+}
+
+func BenchmarkIndividualGets(b *testing.B) {
+	engine, keys := populateBatchEngine(b)
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			engine.Get(key)
+		}
+	}
+}
+
 // ==================== Struct Operations Benchmarks ====================
 
 type BenchmarkUser struct {
@@ -120,6 +152,36 @@ func BenchmarkConcurrentLoad(b *testing.B) {
 	})
 }
 
+// BenchmarkConcurrentStatsRecording 并发命中/未命中统计记录（原子计数器无锁路径）
+func BenchmarkConcurrentStatsRecording(b *testing.B) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("hot", "value", time.Minute)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				cache.GetString("hot")
+			} else {
+				cache.GetString("missing")
+			}
+			i++
+		}
+	})
+}
+
+// BenchmarkConcurrentHotKeyRead 高并发读取同一个键（访问时间原子更新，不加写锁）
+func BenchmarkConcurrentHotKeyRead(b *testing.B) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("hot", "value", time.Minute)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.GetString("hot")
+		}
+	})
+}
+
 func BenchmarkConcurrentReadWrite(b *testing.B) {
 	cache := scache.New(config.DefaultEngineConfig())
 
@@ -144,6 +206,41 @@ func BenchmarkConcurrentReadWrite(b *testing.B) {
 	})
 }
 
+// BenchmarkConcurrencyScaling 读多写少的负载下比较单锁引擎与分片引擎随并发度的吞吐变化
+func BenchmarkConcurrencyScaling(b *testing.B) {
+	for _, shards := range []int{1, 16} {
+		for _, parallelism := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("shards=%d/parallelism=%d", shards, parallelism), func(b *testing.B) {
+				cfg := config.DefaultEngineConfig()
+				cfg.Shards = shards
+				engine := storage.NewStorageEngine(cfg)
+				defer engine.Close()
+
+				keys := make([]string, 10000)
+				for i := range keys {
+					keys[i] = fmt.Sprintf("key-%d", i)
+					engine.Set(keys[i], types.NewStringObject("value", 0))
+				}
+
+				b.SetParallelism(parallelism)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						key := keys[i%len(keys)]
+						if i%10 == 0 {
+							engine.Set(key, types.NewStringObject("value", 0))
+						} else {
+							engine.Get(key)
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
 // ==================== LRU Eviction Benchmarks ====================
 
 func BenchmarkLRUEviction(b *testing.B) {
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	scerrors "github.com/scache-io/scache/errors"
+	"github.com/scache-io/scache/types"
+)
+
+func TestCircularListRetainsLastValues(t *testing.T) {
+	ring := types.NewCircularListObject(5, nil, 0)
+	for i := 1; i <= 8; i++ {
+		ring.Push(i)
+	}
+
+	want := []interface{}{4, 5, 6, 7, 8}
+	if got := ring.Values(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Values() = %v, want %v", got, want)
+	}
+	if got := ring.Range(1, -2); !reflect.DeepEqual(got, []interface{}{5, 6, 7}) {
+		t.Errorf("Range(1, -2) = %v", got)
+	}
+	if v, ok := ring.Index(-1); !ok || v != 8 {
+		t.Errorf("Index(-1) = %v, %v", v, ok)
+	}
+
+	if v, ok := ring.PopFront(); !ok || v != 4 {
+		t.Errorf("PopFront() = %v, %v", v, ok)
+	}
+	if v, ok := ring.Pop(); !ok || v != 8 {
+		t.Errorf("Pop() = %v, %v", v, ok)
+	}
+	ring.PushFront(0)
+	ring.Push(9)
+	ring.Push(10) // 已满，丢弃头部的0
+	if got := ring.Values(); !reflect.DeepEqual(got, []interface{}{5, 6, 7, 9, 10}) {
+		t.Errorf("Values() after wrap = %v", got)
+	}
+}
+
+func TestLocalCachePushCap(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer c.Close()
+
+	for i := 1; i <= 7; i++ {
+		if err := c.RPushCap("r", 5, i); err != nil {
+			t.Fatalf("RPushCap: %v", err)
+		}
+		if err := c.LPushCap("l", 5, i); err != nil {
+			t.Fatalf("LPushCap: %v", err)
+		}
+	}
+
+	if got, _ := c.GetList("r"); !reflect.DeepEqual(got, []interface{}{3, 4, 5, 6, 7}) {
+		t.Errorf("RPushCap list = %v", got)
+	}
+	if got, _ := c.GetList("l"); !reflect.DeepEqual(got, []interface{}{7, 6, 5, 4, 3}) {
+		t.Errorf("LPushCap list = %v", got)
+	}
+
+	c.SetString("s", "v")
+	if err := c.RPushCap("s", 5, 1); !errors.Is(err, scerrors.ErrTypeMismatch) {
+		t.Errorf("RPushCap on string: %v, want ErrTypeMismatch", err)
+	}
+	if err := c.RPushCap("r", 0, 1); !errors.Is(err, scerrors.ErrInvalidArgument) {
+		t.Errorf("RPushCap with zero capacity: %v, want ErrInvalidArgument", err)
+	}
+
+	// 导出导入后保留容量
+	var buf bytes.Buffer
+	if err := c.Export(&buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	restored := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer restored.Close()
+	if err := restored.Import(&buf); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	restored.RPushCap("r", 5, 8)
+	if got, _ := restored.GetList("r"); len(got) != 5 || got[4] != 8 {
+		t.Errorf("restored ring = %v, want 5 values ending with 8", got)
+	}
+}
+
+func TestPushCapConcurrentCreateKeepsAllValues(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer c.Close()
+
+	for trial := 0; trial < 200; trial++ {
+		key := fmt.Sprintf("r:%d", trial)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				c.RPushCap(key, 16, i)
+			}(i)
+		}
+		wg.Wait()
+		if n := c.LLen(key); n != 8 {
+			t.Fatalf("Trial %d: concurrent RPushCap on a new key kept %d of 8 values", trial, n)
+		}
+	}
+}
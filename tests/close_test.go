@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	cachepkg "github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/types"
+)
+
+func TestOperationsAfterClose(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("str", "value")
+	cache.SetHash("hash", map[string]interface{}{"f": 1})
+	cache.Store("struct", map[string]int{"a": 1})
+
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := cache.Close(); err != nil {
+		t.Errorf("Second Close should be a no-op, got %v", err)
+	}
+
+	// 返回error的方法应返回ErrCacheClosed
+	var dest map[string]int
+	var buf bytes.Buffer
+	_, _, getIntErr := cache.GetInt("str")
+	_, _, getStructErr := cachepkg.GetStruct[map[string]int](cache, "struct")
+	errorCalls := map[string]error{
+		"SetString":   cache.SetString("k", "v"),
+		"SetList":     cache.SetList("k", []interface{}{1}),
+		"SetHash":     cache.SetHash("k", map[string]interface{}{"f": 1}),
+		"Store":       cache.Store("k", 1),
+		"Load":        cache.Load("struct", &dest),
+		"GetInt":      getIntErr,
+		"GetStruct":   getStructErr,
+		"HSet":        cache.HSet("hash", "f", 2),
+		"CreateIndex": cache.CreateIndex("*", "f"),
+		"Flush":       cache.Flush(),
+		"Export":      cache.Export(&buf),
+		"Import":      cache.Import(&buf),
+		"Update": cache.Update("struct", 0, func(cur interface{}) (interface{}, error) {
+			return cur, nil
+		}),
+	}
+	for name, err := range errorCalls {
+		if !errors.Is(err, scache.ErrCacheClosed) {
+			t.Errorf("%s after Close: expected ErrCacheClosed, got %v", name, err)
+		}
+	}
+
+	// 其余方法按未命中处理
+	if _, found := cache.GetString("str"); found {
+		t.Error("GetString should miss after Close")
+	}
+	if _, found := cache.GetHash("hash"); found {
+		t.Error("GetHash should miss after Close")
+	}
+	if cache.Exists("str") || cache.Delete("str") || cache.Expire("str", time.Minute) || cache.HDel("hash", "f") {
+		t.Error("Boolean operations should report false after Close")
+	}
+	if cache.Size() != 0 || len(cache.Keys()) != 0 || len(cache.KeysByType(scache.DataTypeString)) != 0 {
+		t.Error("Size and Keys should be empty after Close")
+	}
+	if _, ok := cache.TTL("str"); ok {
+		t.Error("TTL should miss after Close")
+	}
+	if _, ok := cache.GetEngine().Type("str"); ok {
+		t.Error("Type should miss after Close")
+	}
+	if n := cache.ExpireMatching("*", time.Minute); n != 0 {
+		t.Errorf("ExpireMatching should update nothing after Close, got %d", n)
+	}
+	if cache.Stats() == nil {
+		t.Error("Stats should remain readable after Close")
+	}
+}
+
+func TestEngineSetAfterClose(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	engine := cache.GetEngine()
+	engine.Close()
+
+	err := engine.Set("k", types.NewStringObject("v", 0))
+	if !errors.Is(err, scache.ErrCacheClosed) {
+		t.Errorf("Expected ErrCacheClosed from engine Set, got %v", err)
+	}
+	if !engine.Closed() {
+		t.Error("Closed should report true after Close")
+	}
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -438,6 +439,49 @@ func TestGeneratedCodeFormat(t *testing.T) {
 
 // ==================== Integration tests ====================
 
+// TestCMDGenJSON 测试 --json 输出机器可读的摘要
+// 注意：此测试需要网络连接来安装依赖
+func TestCMDGenJSON(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skip test requiring network")
+	}
+
+	binary := buildScacheCMD(t)
+	testdataDir := getTestdataDir(t)
+	generatedFile := filepath.Join(testdataDir, "models_scache.go")
+	defer os.Remove(generatedFile) // 清理
+
+	cmd := exec.Command(binary, "gen", "--generic", "--json", "--dir", testdataDir)
+	output, err := cmd.Output()
+	if err != nil {
+		t.Skipf("跳过: Requires network to install dependencies: %v", err)
+	}
+
+	var summary struct {
+		Files       []string `json:"files"`
+		Structs     []string `json:"structs"`
+		Packages    []string `json:"packages"`
+		FileCount   int      `json:"file_count"`
+		StructCount int      `json:"struct_count"`
+	}
+	if err := json.Unmarshal(output, &summary); err != nil {
+		t.Fatalf("--json output should be valid JSON: %v\noutput: %s", err, string(output))
+	}
+
+	if len(summary.Files) != 1 || summary.Files[0] != generatedFile {
+		t.Errorf("Expected files [%s], got %v", generatedFile, summary.Files)
+	}
+	if summary.FileCount != 1 {
+		t.Errorf("Expected file_count 1, got %d", summary.FileCount)
+	}
+	if summary.StructCount != len(summary.Structs) || summary.StructCount < 3 {
+		t.Errorf("Expected struct_count to match structs list, got %d for %v", summary.StructCount, summary.Structs)
+	}
+	if len(summary.Packages) != 1 || summary.Packages[0] != "models" {
+		t.Errorf("Expected packages [models], got %v", summary.Packages)
+	}
+}
+
 // TestGeneratorDirect 直接测试生成器（不需要网络）
 func TestGeneratorDirect(t *testing.T) {
 	// 这个测试直接使用生成器包，不需要安装依赖
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestIncrDecr(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	// 不存在的键从0开始
+	if n, err := c.Incr("hits"); err != nil || n != 1 {
+		t.Fatalf("Incr on missing key = %d, %v", n, err)
+	}
+	if n, err := c.IncrBy("hits", 10); err != nil || n != 11 {
+		t.Fatalf("IncrBy = %d, %v", n, err)
+	}
+	if n, err := c.Decr("hits"); err != nil || n != 10 {
+		t.Fatalf("Decr = %d, %v", n, err)
+	}
+	if got, _ := c.GetString("hits"); got != "10" {
+		t.Errorf("Expected stored value 10, got %q", got)
+	}
+	if n, err := c.Decr("negative"); err != nil || n != -1 {
+		t.Errorf("Decr on missing key = %d, %v", n, err)
+	}
+}
+
+func TestIncrPreservesTTL(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("counter", "5", time.Hour)
+	if n, err := c.Incr("counter"); err != nil || n != 6 {
+		t.Fatalf("Incr = %d, %v", n, err)
+	}
+	if ttl, ok := c.TTL("counter"); !ok || ttl <= 59*time.Minute {
+		t.Errorf("Expected TTL to be preserved, got %v", ttl)
+	}
+}
+
+func TestIncrErrors(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("text", "abc")
+	if _, err := c.Incr("text"); !errors.Is(err, scache.ErrTypeMismatch) || err.Error() != "type mismatch: value is not an integer" {
+		t.Errorf("Expected not-an-integer error, got %v", err)
+	}
+	c.SetList("list", []interface{}{1})
+	if _, err := c.Incr("list"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for list, got %v", err)
+	}
+	c.SetString("max", strconv.FormatInt(math.MaxInt64, 10))
+	if _, err := c.Incr("max"); !errors.Is(err, scache.ErrInvalidArgument) {
+		t.Errorf("Expected overflow error, got %v", err)
+	}
+	if got, _ := c.GetString("max"); got != strconv.FormatInt(math.MaxInt64, 10) {
+		t.Errorf("Failed increment must not modify the value, got %q", got)
+	}
+}
+
+func TestIncrConcurrentNoLostUpdates(t *testing.T) {
+	for name, c := range map[string]*cache.LocalCache{
+		"plain":       scache.New(config.DefaultEngineConfig()),
+		"transformer": cache.NewLocalCache(config.DefaultEngineConfig().WithValueTransformer(xorTransform, xorTransform)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer c.Close()
+
+			const workers, perWorker = 2, 1000
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < perWorker; i++ {
+						if _, err := c.Incr("counter"); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got, _ := c.GetString("counter"); got != strconv.Itoa(workers*perWorker) {
+				t.Errorf("Expected %d, got %s", workers*perWorker, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/pkg/debugserver"
+)
+
+func getJSON(t *testing.T, url string, dest interface{}) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		t.Fatalf("Decode %s failed: %v", url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestDebugServerStatsAndGet(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("user:1", "Alice", time.Minute)
+	cache.GetString("user:1")
+
+	server := httptest.NewServer(debugserver.Handler(cache.GetEngine()))
+	defer server.Close()
+
+	var stats map[string]interface{}
+	if status := getJSON(t, server.URL+"/stats", &stats); status != http.StatusOK {
+		t.Fatalf("Expected 200 for /stats, got %d", status)
+	}
+	if stats["keys"].(float64) != 1 || stats["hits"].(float64) != 1 {
+		t.Errorf("Unexpected stats payload: %v", stats)
+	}
+
+	var got map[string]interface{}
+	if status := getJSON(t, server.URL+"/get?key=user:1", &got); status != http.StatusOK {
+		t.Fatalf("Expected 200 for /get, got %d", status)
+	}
+	if got["value"] != "Alice" || got["type"] != "string" {
+		t.Errorf("Unexpected /get payload: %v", got)
+	}
+
+	if status := getJSON(t, server.URL+"/get?key=missing", &got); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing key, got %d", status)
+	}
+}
+
+func TestDebugServerKeysPaginationAndReadOnly(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	for _, key := range []string{"c", "a", "b"} {
+		cache.SetString(key, "v")
+	}
+
+	server := httptest.NewServer(debugserver.Handler(cache.GetEngine()))
+	defer server.Close()
+
+	var page struct {
+		Keys    []string `json:"keys"`
+		Total   int      `json:"total"`
+		HasNext bool     `json:"has_next"`
+	}
+	getJSON(t, server.URL+"/keys?page=1&size=2", &page)
+	if page.Total != 3 || !page.HasNext || len(page.Keys) != 2 || page.Keys[0] != "a" || page.Keys[1] != "b" {
+		t.Errorf("Unexpected first page: %+v", page)
+	}
+
+	resp, err := http.Post(server.URL+"/flush", "application/json", nil)
+	if err != nil {
+		t.Fatalf("POST /flush failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || cache.Size() != 3 {
+		t.Errorf("Mutation endpoints should be disabled by default, status=%d size=%d", resp.StatusCode, cache.Size())
+	}
+
+	mutable := httptest.NewServer(debugserver.NewHandler(cache.GetEngine(), &debugserver.Config{AllowMutation: true}))
+	defer mutable.Close()
+
+	resp, err = http.Post(mutable.URL+"/flush", "application/json", nil)
+	if err != nil {
+		t.Fatalf("POST /flush failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || cache.Size() != 0 {
+		t.Errorf("Expected flush to succeed when mutation is enabled, status=%d size=%d", resp.StatusCode, cache.Size())
+	}
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestEngineDeleteMany(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+
+	engine.Set("a", types.NewStringObject("v", 0))
+	engine.Set("b", types.NewListObject([]interface{}{"x"}, 0))
+	engine.Set("c", types.NewStringObject("v", 0))
+
+	removed, results := engine.DeleteMany([]string{"a", "missing", "b", "a", ""})
+	if removed != 2 {
+		t.Errorf("Expected 2 removed, got %d", removed)
+	}
+	want := map[string]bool{"a": true, "missing": false, "b": true, "": false}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+	if engine.Size() != 1 || !engine.Exists("c") {
+		t.Errorf("Only c should remain, got keys %v", engine.Keys())
+	}
+}
+
+func TestCacheDeleteMany(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cache := scache.New(cfg)
+	defer cache.Close()
+
+	cache.SetString("k1", "v")
+	cache.SetString("k2", "v", time.Hour)
+
+	removed, results := cache.DeleteMany("k1", "k2", "k3")
+	if removed != 2 || !results["k1"] || !results["k2"] || results["k3"] {
+		t.Errorf("DeleteMany = %d, %v", removed, results)
+	}
+
+	// 删除后低于容量，可以重新写满
+	for _, key := range []string{"x", "y"} {
+		if err := cache.SetString(key, "v"); err != nil {
+			t.Errorf("SetString(%s) after DeleteMany: %v", key, err)
+		}
+	}
+}
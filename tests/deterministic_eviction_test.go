@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/policies/ttllru"
+	"github.com/scache-io/scache/types"
+)
+
+func TestDeterministicEvictionMSetOrder(t *testing.T) {
+	const capacity = 5
+	for trial := 0; trial < 20; trial++ {
+		var evicted []string
+		cfg := config.DefaultEngineConfig()
+		cfg.MaxSize = capacity
+		cfg.BackgroundCleanupInterval = time.Minute
+		cfg.DeterministicEviction = true
+		cfg.OnEvict = func(key string, obj interfaces.DataObject) {
+			evicted = append(evicted, key)
+		}
+		c := scache.New(cfg)
+
+		// 一次写入N+1个键，按键排序写入，最先写入的k0被淘汰
+		objs := make(map[string]interfaces.DataObject, capacity+1)
+		for i := 0; i <= capacity; i++ {
+			objs[fmt.Sprintf("k%d", i)] = types.NewStringObject("v", 0)
+		}
+		if err := c.GetEngine().MSet(objs); err != nil {
+			t.Fatalf("MSet failed: %v", err)
+		}
+		c.Close()
+
+		if !reflect.DeepEqual(evicted, []string{"k0"}) {
+			t.Fatalf("Trial %d: expected k0 evicted, got %v", trial, evicted)
+		}
+	}
+}
+
+func TestDeterministicEvictionEqualTTLTies(t *testing.T) {
+	const capacity = 5
+	expiresAt := time.Now().Add(time.Hour)
+	for trial := 0; trial < 20; trial++ {
+		var evicted []string
+		cfg := config.DefaultEngineConfig()
+		cfg.MaxSize = capacity
+		cfg.BackgroundCleanupInterval = time.Minute
+		cfg.PolicyFactory = ttllru.NewTTLLRUPolicy
+		cfg.DeterministicEviction = true
+		cfg.OnEvict = func(key string, obj interfaces.DataObject) {
+			evicted = append(evicted, key)
+		}
+		c := scache.New(cfg)
+
+		// 所有键过期时间完全相同，按设置顺序淘汰
+		for i := 0; i <= capacity+2; i++ {
+			obj := types.NewStringObject("v", time.Hour)
+			obj.SetExpiresAt(expiresAt)
+			c.GetEngine().Set(fmt.Sprintf("k%d", i), obj)
+		}
+		c.Close()
+
+		if !reflect.DeepEqual(evicted, []string{"k0", "k1", "k2"}) {
+			t.Fatalf("Trial %d: expected k0, k1, k2 evicted, got %v", trial, evicted)
+		}
+	}
+}
+
+func TestTTLLRUPolicyTieBreakUsesSetOrder(t *testing.T) {
+	policy := ttllru.NewTTLLRUPolicy(10).(interfaces.ExpiryAwarePolicy)
+	expiresAt := time.Now().Add(time.Hour)
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		policy.Set(key)
+		policy.SetExpiry(key, expiresAt)
+	}
+	// 重新设置过期时间的键排到相同过期时间的键之后
+	policy.SetExpiry("a", expiresAt)
+
+	for _, want := range []string{"b", "c", "d", "a"} {
+		if got := policy.Evict(); got != want {
+			t.Fatalf("Expected eviction of %s, got %s", want, got)
+		}
+	}
+}
+
+func TestInjectedClockDrivesExpiry(t *testing.T) {
+	var offset atomic.Int64
+	cfg := config.DefaultEngineConfig()
+	cfg.Clock = func() time.Time {
+		return time.Now().Add(time.Duration(offset.Load()))
+	}
+	cfg.IdleTimeout = 10 * time.Minute
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.SetString("short", "v", time.Minute)
+	c.SetString("idle", "v")
+
+	if !c.Exists("short") || !c.Exists("idle") {
+		t.Fatal("Keys should be alive before the clock advances")
+	}
+
+	offset.Store(int64(2 * time.Minute))
+	if c.Exists("short") {
+		t.Error("Key should expire once the injected clock passes its TTL")
+	}
+	if !c.Exists("idle") {
+		t.Error("Idle key should survive until the idle timeout")
+	}
+
+	offset.Store(int64(20 * time.Minute))
+	if c.Exists("idle") {
+		t.Error("Idle timeout should use the injected clock")
+	}
+}
+
+func TestInjectedClockDrivesAllTTLArithmetic(t *testing.T) {
+	base := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	var elapsed atomic.Int64
+	cfg := config.DefaultEngineConfig()
+	cfg.Clock = func() time.Time { return base.Add(time.Duration(elapsed.Load())) }
+	cfg.SlidingTTLPatterns = []string{"session:*"}
+	c := scache.New(cfg)
+	defer c.Close()
+
+	// 时钟远早于系统时间，写入的TTL仍从注入的时钟开始计时
+	c.SetString("k", "v", time.Minute)
+	if ttl, _ := c.TTL("k"); ttl != time.Minute {
+		t.Errorf("TTL = %v, want exactly 1m", ttl)
+	}
+	if got := c.PTTL("k"); got != 60000 {
+		t.Errorf("PTTL = %d, want 60000", got)
+	}
+
+	elapsed.Store(int64(20 * time.Second))
+	if got := c.MTTL("k"); got[0] != 40 {
+		t.Errorf("MTTL = %v, want [40]", got)
+	}
+	c.Expire("k", 2*time.Minute)
+	if ttl, _ := c.TTL("k"); ttl != 2*time.Minute {
+		t.Errorf("TTL after Expire = %v, want exactly 2m", ttl)
+	}
+	c.ExpireMatching("k", 3*time.Minute)
+	if ttl, _ := c.TTL("k"); ttl != 3*time.Minute {
+		t.Errorf("TTL after ExpireMatching = %v, want exactly 3m", ttl)
+	}
+
+	c.SetString("session:1", "v", time.Minute)
+	elapsed.Store(int64(70 * time.Second))
+	c.GetString("session:1")
+	if ttl, _ := c.TTL("session:1"); ttl != time.Minute {
+		t.Errorf("Sliding TTL = %v, want exactly 1m", ttl)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	restored := scache.New(cfg)
+	defer restored.Close()
+	if err := restored.Import(&buf); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if ttl, _ := restored.TTL("k"); ttl != 2*time.Minute+10*time.Second {
+		t.Errorf("Imported TTL = %v, want exactly 2m10s", ttl)
+	}
+
+	elapsed.Store(int64(time.Hour))
+	if c.Exists("k") || restored.Exists("k") {
+		t.Error("Keys should expire once the injected clock passes their TTL")
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestEmptyStringEngine(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+
+	engine.Set("empty", types.NewStringObject("", 0))
+
+	obj, ok := engine.Get("empty")
+	if !ok {
+		t.Fatal("Empty string value should be found")
+	}
+	if got := obj.(*types.StringObject).Value(); got != "" {
+		t.Errorf("Expected empty value, got %q", got)
+	}
+	if !engine.Exists("empty") {
+		t.Error("Exists should report an empty string value")
+	}
+	if _, ok := engine.Get("missing"); ok {
+		t.Error("Missing key should not be found")
+	}
+
+	// 空字符串经导出/导入后仍然存在
+	var buf bytes.Buffer
+	if err := engine.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	restored := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer restored.Close()
+	if err := restored.Import(&buf); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !restored.Exists("empty") {
+		t.Error("Empty string value should survive a snapshot round trip")
+	}
+}
+
+func TestEmptyStringLocalCache(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if err := c.SetString("empty", ""); err != nil {
+		t.Fatalf("SetString failed: %v", err)
+	}
+	if got, ok := c.GetString("empty"); !ok || got != "" {
+		t.Errorf("GetString = %q, %v; want \"\", true", got, ok)
+	}
+	if !c.Exists("empty") {
+		t.Error("Exists should report an empty string value")
+	}
+	if _, ok := c.GetString("missing"); ok {
+		t.Error("Missing key should not be found")
+	}
+	if got, ok := c.GetStringAndDelete("empty"); !ok || got != "" {
+		t.Errorf("GetStringAndDelete = %q, %v; want \"\", true", got, ok)
+	}
+	if c.Exists("empty") {
+		t.Error("Key should be deleted")
+	}
+}
+
+func TestEmptyStringGlobal(t *testing.T) {
+	defer scache.Delete("empty-global")
+
+	if err := scache.SetString("empty-global", ""); err != nil {
+		t.Fatalf("SetString failed: %v", err)
+	}
+	if got, ok := scache.GetString("empty-global"); !ok || got != "" {
+		t.Errorf("GetString = %q, %v; want \"\", true", got, ok)
+	}
+	if !scache.Exists("empty-global") {
+		t.Error("Exists should report an empty string value")
+	}
+	if _, ok := scache.GetString("missing-global"); ok {
+		t.Error("Missing key should not be found")
+	}
+}
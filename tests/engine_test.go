@@ -0,0 +1,540 @@
+package tests
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/pkg/workerpool"
+	"github.com/scache-io/scache/policies/ttllru"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+// heapInUse 强制GC后读取堆内存使用量
+func heapInUse() uint64 {
+	runtime.GC()
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// ==================== 压缩测试 ====================
+
+func TestCompactReclaimsMemory(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	cache := scache.New(cfg)
+
+	const total = 200000
+	for i := 0; i < total; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	// 删除90%的数据
+	for i := 0; i < total*9/10; i++ {
+		cache.Delete(fmt.Sprintf("key:%d", i))
+	}
+
+	before := heapInUse()
+	cache.Compact()
+	after := heapInUse()
+
+	if after >= before {
+		t.Errorf("Expected heap usage to drop after Compact, before=%d after=%d", before, after)
+	}
+	if cache.Size() != total/10 {
+		t.Errorf("Expected %d keys after Compact, got %d", total/10, cache.Size())
+	}
+	if v, found := cache.GetString(fmt.Sprintf("key:%d", total-1)); !found || v != "v" {
+		t.Error("Live entries should survive Compact")
+	}
+}
+
+func TestAutoCompactThreshold(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.CompactThreshold = 0.25
+	cache := scache.New(cfg)
+
+	for i := 0; i < 4096; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	for i := 0; i < 4000; i++ {
+		cache.Delete(fmt.Sprintf("key:%d", i))
+	}
+
+	if cache.Size() != 96 {
+		t.Errorf("Expected 96 keys, got %d", cache.Size())
+	}
+	for i := 4000; i < 4096; i++ {
+		if !cache.Exists(fmt.Sprintf("key:%d", i)) {
+			t.Fatalf("key:%d should survive automatic compaction", i)
+		}
+	}
+}
+
+// ==================== 键规范化测试 ====================
+
+func TestKeyNormalizer(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.KeyNormalizer = strings.ToLower
+	cache := scache.New(cfg)
+
+	if err := cache.SetString("key", "value", time.Minute); err != nil {
+		t.Fatalf("SetString failed: %v", err)
+	}
+
+	if value, found := cache.GetString("KEY"); !found || value != "value" {
+		t.Errorf("Expected GetString(\"KEY\") to find 'value', got %q, %v", value, found)
+	}
+	if !cache.Exists("Key") {
+		t.Error("Exists should apply the normalizer")
+	}
+	if ttl, ok := cache.TTL("KEY"); !ok || ttl <= 0 {
+		t.Errorf("TTL should apply the normalizer, got %v, %v", ttl, ok)
+	}
+	if !cache.Expire("KEY", time.Hour) {
+		t.Error("Expire should apply the normalizer")
+	}
+
+	cache.SetString("MiXeD", "v")
+	keys := cache.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "key" || keys[1] != "mixed" {
+		t.Errorf("Keys should return normalized form, got %v", keys)
+	}
+
+	if !cache.Delete("KEY") {
+		t.Error("Delete should apply the normalizer")
+	}
+	if cache.Exists("key") {
+		t.Error("Key should not exist after deletion")
+	}
+}
+
+// ==================== 容量回调测试 ====================
+
+func TestOnFullCallback(t *testing.T) {
+	var calls []int
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 5
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.OnFull = func(currentSize, maxSize int) {
+		if maxSize != 5 {
+			t.Errorf("Expected maxSize 5, got %d", maxSize)
+		}
+		calls = append(calls, currentSize)
+	}
+	cache := scache.New(cfg)
+
+	for i := 0; i < 5; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	if len(calls) != 0 {
+		t.Fatalf("OnFull should not fire before capacity is exceeded, got %d calls", len(calls))
+	}
+
+	// 达到容量后的多次写入只触发一次
+	for i := 5; i < 10; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	if len(calls) != 1 || calls[0] != 5 {
+		t.Fatalf("Expected OnFull to fire once with size 5, got %v", calls)
+	}
+
+	// 更新已有键不触发
+	cache.SetString("key:9", "updated")
+	if len(calls) != 1 {
+		t.Fatalf("Updating an existing key should not fire OnFull, got %d calls", len(calls))
+	}
+
+	// 降到容量以下后再次填满会重新触发
+	cache.Delete("key:9")
+	cache.SetString("key:10", "v")
+	cache.SetString("key:11", "v")
+	if len(calls) != 2 {
+		t.Errorf("Expected OnFull to fire again after refilling, got %d calls", len(calls))
+	}
+}
+
+func TestOnSoftLimitCallback(t *testing.T) {
+	var calls []int
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 10
+	cfg.SoftLimitRatio = 0.8
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.OnSoftLimit = func(currentSize, maxSize int) {
+		if maxSize != 10 {
+			t.Errorf("Expected maxSize 10, got %d", maxSize)
+		}
+		calls = append(calls, currentSize)
+	}
+	cache := scache.New(cfg)
+
+	for i := 0; i < 7; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	if len(calls) != 0 {
+		t.Fatalf("OnSoftLimit should not fire below 80%%, got %d calls", len(calls))
+	}
+
+	// 越过80%后的多次写入只触发一次
+	for i := 7; i < 10; i++ {
+		cache.SetString(fmt.Sprintf("key:%d", i), "v")
+	}
+	if len(calls) != 1 || calls[0] != 8 {
+		t.Fatalf("Expected OnSoftLimit to fire once with size 8, got %v", calls)
+	}
+
+	// 在回落区间内抖动不会重复触发
+	cache.Delete("key:9")
+	cache.Delete("key:8")
+	cache.SetString("key:8", "v")
+	if len(calls) != 1 {
+		t.Fatalf("Hovering around the threshold should not re-fire, got %d calls", len(calls))
+	}
+
+	// 回落到阈值以下足够多后再次越过会重新触发
+	cache.Delete("key:8")
+	cache.Delete("key:7")
+	cache.Delete("key:6")
+	cache.SetString("key:6", "v")
+	cache.SetString("key:7", "v")
+	if len(calls) != 2 {
+		t.Errorf("Expected OnSoftLimit to fire again after re-crossing, got %d calls", len(calls))
+	}
+}
+
+// ==================== 批量读取测试 ====================
+
+func TestMGetTouchPromotesKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 4
+	cfg.BackgroundCleanupInterval = time.Minute
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		engine.Set(key, types.NewStringObject(key, 0))
+	}
+
+	got := engine.MGetTouch([]string{"a", "b", "missing"})
+	if len(got) != 2 || got["a"] == nil || got["b"] == nil {
+		t.Fatalf("MGetTouch returned %v, want a and b", got)
+	}
+
+	// a、b被提升后，后续写入依次淘汰c、d
+	engine.Set("e", types.NewStringObject("e", 0))
+	engine.Set("f", types.NewStringObject("f", 0))
+	for _, key := range []string{"a", "b", "e", "f"} {
+		if !engine.Exists(key) {
+			t.Errorf("Expected %s to survive eviction", key)
+		}
+	}
+	for _, key := range []string{"c", "d"} {
+		if engine.Exists(key) {
+			t.Errorf("Expected %s to be evicted", key)
+		}
+	}
+}
+
+// ==================== 条件重命名测试 ====================
+
+func TestRenameIf(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetString("staging:cfg", "v1", time.Hour)
+	cache.SetString("live:cfg", "v0")
+
+	// 读取后src被并发覆盖，按旧值重命名失败且两个键保持不变
+	observed, _ := cache.GetString("staging:cfg")
+	cache.SetString("staging:cfg", "v2", time.Hour)
+	if cache.RenameIf("staging:cfg", "live:cfg", observed) {
+		t.Fatal("RenameIf should fail after src changed")
+	}
+	if v, _ := cache.GetString("staging:cfg"); v != "v2" {
+		t.Errorf("src should keep the new value, got %q", v)
+	}
+	if v, _ := cache.GetString("live:cfg"); v != "v0" {
+		t.Errorf("dst should be unchanged, got %q", v)
+	}
+
+	// 值匹配时移动键并保留TTL
+	if !cache.RenameIf("staging:cfg", "live:cfg", "v2") {
+		t.Fatal("RenameIf should succeed when the value matches")
+	}
+	if cache.Exists("staging:cfg") {
+		t.Error("src should be removed after rename")
+	}
+	if v, _ := cache.GetString("live:cfg"); v != "v2" {
+		t.Errorf("dst should hold the renamed value, got %q", v)
+	}
+	if ttl, _ := cache.TTL("live:cfg"); ttl <= 59*time.Minute {
+		t.Errorf("TTL should move with the key, got %v", ttl)
+	}
+
+	cache.SetList("list", []interface{}{"v2"})
+	if cache.RenameIf("list", "other", "v2") {
+		t.Error("RenameIf should not rename non-string values")
+	}
+	if cache.RenameIf("missing", "other", "") {
+		t.Error("RenameIf should fail for a missing src")
+	}
+}
+
+func TestRenameIfConcurrentOverwrite(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	// 并发的覆盖与条件重命名：重命名成功时dst一定是被比较的值
+	for i := 0; i < 200; i++ {
+		cache.Delete("dst")
+		cache.SetString("src", "old")
+
+		done := make(chan struct{})
+		go func() {
+			cache.SetString("src", "new")
+			close(done)
+		}()
+		renamed := cache.RenameIf("src", "dst", "old")
+		<-done
+
+		dst, dstExists := cache.GetString("dst")
+		src, _ := cache.GetString("src")
+		if renamed && (!dstExists || dst != "old") {
+			t.Fatalf("Renamed but dst = %q, %v", dst, dstExists)
+		}
+		if !renamed && (dstExists || src != "new") {
+			t.Fatalf("Rename failed but dst exists=%v, src=%q", dstExists, src)
+		}
+	}
+}
+
+// ==================== 自适应清理测试 ====================
+
+func newAdaptiveEngine(pool *workerpool.Pool) *storage.StorageEngine {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = 8 * time.Millisecond
+	cfg.AdaptiveCleanup = true
+	cfg.MinCleanupInterval = time.Millisecond
+	cfg.MaxCleanupInterval = 64 * time.Millisecond
+	cfg.WorkerPool = pool
+	return storage.NewStorageEngine(cfg).(*storage.StorageEngine)
+}
+
+// waitForInterval 等待清理间隔达到want，期间每轮调用step
+func waitForInterval(t *testing.T, engine *storage.StorageEngine, want time.Duration, step func(i int)) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for i := 0; time.Now().Before(deadline); i++ {
+		if engine.CleanupInterval() == want {
+			return
+		}
+		step(i)
+	}
+	t.Fatalf("Cleanup interval = %v, want %v", engine.CleanupInterval(), want)
+}
+
+func TestAdaptiveCleanupShrinksUnderHeavyExpiry(t *testing.T) {
+	engine := newAdaptiveEngine(nil)
+	defer engine.Close()
+
+	// 持续写入很快过期的键，使每轮清理都能回收大部分键
+	waitForInterval(t, engine, time.Millisecond, func(i int) {
+		for j := 0; j < 20; j++ {
+			engine.Set(fmt.Sprintf("key:%d:%d", i, j), types.NewStringObject("v", time.Microsecond))
+		}
+		time.Sleep(100 * time.Microsecond)
+	})
+}
+
+func TestAdaptiveCleanupGrowsWhenIdle(t *testing.T) {
+	engine := newAdaptiveEngine(nil)
+	defer engine.Close()
+	engine.Set("permanent", types.NewStringObject("v", 0))
+
+	waitForInterval(t, engine, 64*time.Millisecond, func(int) {
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	stats := engine.Stats().(map[string]interface{})
+	if stats["cleanup_interval"] != 64*time.Millisecond {
+		t.Errorf("Expected cleanup_interval in stats, got %v", stats["cleanup_interval"])
+	}
+}
+
+func TestAdaptiveCleanupWithWorkerPool(t *testing.T) {
+	pool := workerpool.New(1)
+	defer pool.Close()
+	engine := newAdaptiveEngine(pool)
+	defer engine.Close()
+
+	waitForInterval(t, engine, 64*time.Millisecond, func(int) {
+		time.Sleep(5 * time.Millisecond)
+	})
+}
+
+// firstCleanupAt 在引擎中写入立即过期的键，轮询直到后台清理将其回收，返回回收时刻
+func firstCleanupAt(engine interfaces.StorageEngine, done chan<- time.Time) {
+	engine.Set("expired", types.NewStringObject("v", time.Nanosecond))
+	for engine.Size() > 0 {
+		time.Sleep(500 * time.Microsecond)
+	}
+	done <- time.Now()
+}
+
+func TestCleanupJitterDesynchronizesEngines(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = 10 * time.Millisecond
+	cfg.CleanupJitter = 200 * time.Millisecond
+
+	// 两次首轮清理落在同一区间的概率约为20%，多次尝试中至少一次应明显错开
+	var gaps []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		a, b := storage.NewStorageEngine(cfg), storage.NewStorageEngine(cfg)
+		doneA, doneB := make(chan time.Time, 1), make(chan time.Time, 1)
+		go firstCleanupAt(a, doneA)
+		go firstCleanupAt(b, doneB)
+
+		gap := (<-doneA).Sub(<-doneB).Abs()
+		a.Close()
+		b.Close()
+		if gap > 2*cfg.BackgroundCleanupInterval {
+			return
+		}
+		gaps = append(gaps, gap)
+	}
+	t.Errorf("Cleanup passes stayed aligned across attempts: %v", gaps)
+}
+
+func TestCleanupJitterDefaultsToFractionOfInterval(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	if got := cfg.CleanupJitterRange(); got != 6*time.Second {
+		t.Errorf("Expected default jitter of 6s, got %v", got)
+	}
+
+	cfg.CleanupJitter = time.Second
+	if got := cfg.CleanupJitterRange(); got != time.Second {
+		t.Errorf("Expected configured jitter of 1s, got %v", got)
+	}
+}
+
+// ==================== 按类型列出键测试 ====================
+
+func TestKeysByType(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetString("s1", "a")
+	cache.SetString("s2", "b")
+	cache.SetList("l1", []interface{}{1, 2})
+	cache.SetList("l2", []interface{}{3})
+	cache.SetList("l_expired", []interface{}{4}, time.Millisecond)
+	cache.SetHash("h1", map[string]interface{}{"f": 1})
+
+	time.Sleep(5 * time.Millisecond)
+
+	keys := cache.KeysByType(scache.DataTypeList)
+	sort.Strings(keys)
+	if strings.Join(keys, ",") != "l1,l2" {
+		t.Errorf("Expected list keys [l1 l2], got %v", keys)
+	}
+
+	if keys := cache.KeysByType(scache.DataTypeHash); len(keys) != 1 || keys[0] != "h1" {
+		t.Errorf("Expected hash keys [h1], got %v", keys)
+	}
+	if keys := cache.KeysByType(scache.DataTypeString); len(keys) != 2 {
+		t.Errorf("Expected 2 string keys, got %v", keys)
+	}
+}
+
+// ==================== 配置校验测试 ====================
+
+func TestEngineConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.EngineConfig)
+	}{
+		{"memory threshold above 1", func(cfg *config.EngineConfig) { cfg.MemoryThreshold = 1.5 }},
+		{"negative memory threshold", func(cfg *config.EngineConfig) { cfg.MemoryThreshold = -0.1 }},
+		{"negative cleanup interval", func(cfg *config.EngineConfig) { cfg.BackgroundCleanupInterval = -time.Second }},
+		{"negative max size", func(cfg *config.EngineConfig) { cfg.MaxSize = -1 }},
+		{"compact threshold above 1", func(cfg *config.EngineConfig) { cfg.CompactThreshold = 2 }},
+		{"min cleanup interval above max", func(cfg *config.EngineConfig) {
+			cfg.MinCleanupInterval = time.Minute
+			cfg.MaxCleanupInterval = time.Second
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultEngineConfig()
+			tt.modify(cfg)
+
+			if err := cfg.Validate(); err == nil {
+				t.Fatal("Validate should reject invalid config")
+			}
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Constructing an engine with invalid config should panic")
+				} else if !strings.Contains(fmt.Sprint(r), "invalid engine config") {
+					t.Errorf("Panic message should describe the invalid config, got %v", r)
+				}
+			}()
+			scache.New(cfg)
+		})
+	}
+
+	if err := config.DefaultEngineConfig().Validate(); err != nil {
+		t.Errorf("Default config should be valid: %v", err)
+	}
+}
+
+// ==================== 运行信息测试 ====================
+
+func TestStatsIncludesUptimeAndConfig(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 100
+	cfg.DefaultExpiration = time.Hour
+	cfg.PolicyFactory = ttllru.NewTTLLRUPolicy
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	engine.Set("k", types.NewStringObject("v", 0))
+	engine.Get("k")
+	time.Sleep(5 * time.Millisecond)
+
+	stats := engine.Stats().(map[string]interface{})
+	if got := stats["policy_name"]; got != "ttl-lru" {
+		t.Errorf("Expected policy_name ttl-lru, got %v", got)
+	}
+	if got := stats["max_size"]; got != 100 {
+		t.Errorf("Expected max_size 100, got %v", got)
+	}
+	if got := stats["default_expiration"]; got != time.Hour {
+		t.Errorf("Expected default_expiration 1h, got %v", got)
+	}
+	if uptime := stats["uptime"].(time.Duration); uptime < 5*time.Millisecond {
+		t.Errorf("Expected uptime of at least 5ms, got %v", uptime)
+	}
+	if startedAt := stats["started_at"].(time.Time); time.Since(startedAt) > time.Minute {
+		t.Errorf("Unexpected started_at %v", startedAt)
+	}
+	if rate := stats["ops_per_sec"].(float64); rate <= 0 {
+		t.Errorf("Expected positive ops_per_sec, got %v", rate)
+	}
+
+	unbounded := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer unbounded.Close()
+	if got := unbounded.Stats().(map[string]interface{})["policy_name"]; got != "none" {
+		t.Errorf("Expected unbounded engine to report policy none, got %v", got)
+	}
+}
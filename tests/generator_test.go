@@ -1,11 +1,14 @@
 package tests
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/scache-io/scache/cmd/scache/generator"
 )
@@ -376,6 +379,162 @@ func TestGeneratedCodeUsage(t *testing.T) {
 
 // ==================== Edge case tests ====================
 
+func TestGeneratorRecordsGeneratedFiles(t *testing.T) {
+	testdataDir := getTestdataDir(t)
+	outputFile := filepath.Join(testdataDir, "models_scache.go")
+	defer os.Remove(outputFile)
+
+	cfg := &generator.Config{
+		Dir:           testdataDir,
+		Package:       "models",
+		ExcludeDirs:   []string{"vendor", "node_modules", ".git"},
+		TargetStructs: []string{"User", "Order"},
+		UseGeneric:    true,
+	}
+
+	if err := generator.Generate(cfg); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	if len(cfg.GeneratedFiles) != 1 || cfg.GeneratedFiles[0] != outputFile {
+		t.Errorf("Expected generated files [%s], got %v", outputFile, cfg.GeneratedFiles)
+	}
+	if strings.Join(cfg.GeneratedStructs, ",") != "Order,User" {
+		t.Errorf("Expected sorted structs [Order User], got %v", cfg.GeneratedStructs)
+	}
+	if len(cfg.GeneratedPkgs) != 1 || cfg.GeneratedPkgs[0] != "models" {
+		t.Errorf("Expected packages [models], got %v", cfg.GeneratedPkgs)
+	}
+}
+
+// writeAnnotatedModels 写入带注解的测试模型
+func writeAnnotatedModels(t *testing.T) string {
+	dir := t.TempDir()
+	src := `package annotated
+
+// Account 显式标注需要缓存
+// scache:"cache"
+type Account struct {
+	ID     int    ` + "`json:\"id\"`" + `
+	Secret string ` + "`json:\"-\" scache:\"-\"`" + `
+}
+
+// Session 未标注
+type Session struct {
+	Token string
+}
+
+// AuditLog 显式跳过
+// scache:"-"
+type AuditLog struct {
+	Entry string
+}
+
+type internalState struct {
+	count int
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0644); err != nil {
+		t.Fatalf("Failed to write test models: %v", err)
+	}
+	return dir
+}
+
+func TestGeneratorSkipsAnnotatedAndUnexported(t *testing.T) {
+	dir := writeAnnotatedModels(t)
+
+	cfg := &generator.Config{Dir: dir, Package: "annotated", UseGeneric: true}
+	if err := generator.Generate(cfg); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	if got := strings.Join(cfg.GeneratedStructs, ","); got != "Account,Session" {
+		t.Errorf("Expected structs [Account Session], got %v", cfg.GeneratedStructs)
+	}
+
+	// 显式包含未导出结构体
+	cfg = &generator.Config{Dir: dir, Package: "annotated", UseGeneric: true, IncludeUnexported: true}
+	if err := generator.Generate(cfg); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if got := strings.Join(cfg.GeneratedStructs, ","); got != "Account,Session,internalState" {
+		t.Errorf("Expected structs [Account Session internalState], got %v", cfg.GeneratedStructs)
+	}
+}
+
+func TestGeneratorOnlyTagged(t *testing.T) {
+	dir := writeAnnotatedModels(t)
+
+	cfg := &generator.Config{Dir: dir, Package: "annotated", UseGeneric: true, OnlyTagged: true}
+	if err := generator.Generate(cfg); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	if got := strings.Join(cfg.GeneratedStructs, ","); got != "Account" {
+		t.Errorf("Expected only [Account], got %v", cfg.GeneratedStructs)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "annotated_scache.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if !strings.Contains(string(content), "GetAccountScache") {
+		t.Error("Generated code should contain GetAccountScache")
+	}
+	if strings.Contains(string(content), "GetSessionScache") {
+		t.Error("Untagged struct should not be generated in only-tagged mode")
+	}
+}
+
+func TestGeneratorDefaultTTL(t *testing.T) {
+	for _, useGeneric := range []bool{true, false} {
+		dir := t.TempDir()
+		if err := copyTestdata(getTestdataDir(t), dir); err != nil {
+			t.Fatalf("Failed to copy test data: %v", err)
+		}
+
+		cfg := &generator.Config{
+			Dir:           dir,
+			Package:       "models",
+			TargetStructs: []string{"User"},
+			UseGeneric:    useGeneric,
+			DefaultTTL:    10 * time.Minute,
+		}
+		if err := generator.Generate(cfg); err != nil {
+			t.Fatalf("Failed to generate code: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "models_scache.go"))
+		if err != nil {
+			t.Fatalf("Failed to read generated file: %v", err)
+		}
+		contentStr := string(content)
+
+		recv := "UserScache"
+		if useGeneric {
+			recv = "Scache[T]"
+		}
+		expected := []string{
+			"const scacheDefaultTTL = 10 * time.Minute",
+			"s.defaultTTL.Store(int64(scacheDefaultTTL))",
+			"func (s *" + recv + ") StoreWithDefault(key string, obj ",
+			"return s.cache.Store(key, obj, s.DefaultTTL())",
+			"func (s *" + recv + ") SetDefaultTTL(ttl time.Duration) {\n\ts.defaultTTL.Store(int64(ttl))",
+			"func (s *" + recv + ") DefaultTTL() time.Duration {\n\treturn time.Duration(s.defaultTTL.Load())",
+		}
+		for _, elem := range expected {
+			if !strings.Contains(contentStr, elem) {
+				t.Errorf("Generated code (generic=%v) should contain %q", useGeneric, elem)
+			}
+		}
+
+		// 生成的代码应当是合法的Go代码
+		if _, err := parser.ParseFile(token.NewFileSet(), "models_scache.go", content, 0); err != nil {
+			t.Errorf("Generated code (generic=%v) should parse: %v", useGeneric, err)
+		}
+	}
+}
+
 func TestGeneratorEmptyStructs(t *testing.T) {
 	// 创建一个临时目录，没有Struct
 	tempDir := t.TempDir()
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestEngineGetAndDelete(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+
+	engine.Set("k", types.NewStringObject("v", 0))
+
+	// 类型不匹配时保留原值
+	if _, ok := engine.GetAndDelete("k", interfaces.DataTypeList); ok {
+		t.Fatal("GetAndDelete with mismatched type should miss")
+	}
+	if !engine.Exists("k") {
+		t.Fatal("mismatched GetAndDelete must not delete the key")
+	}
+
+	obj, ok := engine.GetAndDelete("k")
+	if !ok || obj.(*types.StringObject).Value() != "v" {
+		t.Fatalf("GetAndDelete = %v, %v", obj, ok)
+	}
+	if _, ok := engine.GetAndDelete("k"); ok {
+		t.Error("second GetAndDelete should miss")
+	}
+}
+
+func TestLocalCacheGetAndDelete(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetList("list", []interface{}{"a", "b"})
+	if v, ok := c.GetAndDelete("list"); !ok || !reflect.DeepEqual(v, []interface{}{"a", "b"}) {
+		t.Fatalf("GetAndDelete(list) = %v, %v", v, ok)
+	}
+	if _, ok := c.GetAndDelete("list"); ok {
+		t.Error("second GetAndDelete should miss")
+	}
+
+	c.SetHash("hash", map[string]interface{}{"f": 1})
+	if _, ok := c.GetStringAndDelete("hash"); ok {
+		t.Error("GetStringAndDelete on a hash should miss")
+	}
+	if !c.Exists("hash") {
+		t.Error("GetStringAndDelete on a hash must not delete it")
+	}
+
+	// 并发读取删除同一个键，只有一个调用方拿到值
+	c.SetString("token", "once")
+	var winners atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, ok := c.GetStringAndDelete("token"); ok && v == "once" {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := winners.Load(); got != 1 {
+		t.Errorf("%d callers got the value, want exactly 1", got)
+	}
+}
+
+func TestGlobalGetStringAndDelete(t *testing.T) {
+	scache.SetString("getdel:global", "v")
+	if v, ok := scache.GetStringAndDelete("getdel:global"); !ok || v != "v" {
+		t.Fatalf("GetStringAndDelete = %q, %v", v, ok)
+	}
+	if _, ok := scache.GetStringAndDelete("getdel:global"); ok {
+		t.Error("second GetStringAndDelete should miss")
+	}
+	if _, ok := scache.GetAndDelete("getdel:global"); ok {
+		t.Error("GetAndDelete after delete should miss")
+	}
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+)
+
+type profile struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestGetOrStoreStructLoadsOnceUnderConcurrency(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	var calls atomic.Int32
+	want := profile{Name: "alice", Age: 30}
+	loader := func() (profile, error) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return want, nil
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			got, err := scache.GetOrStore(c, "user:1", time.Minute, loader)
+			if err != nil || got != want {
+				t.Errorf("GetOrStore = %+v, %v", got, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected loader to run once, ran %d times", n)
+	}
+
+	// 加载的值已写入，可按类型读取，再次调用直接命中
+	if got, found, err := cache.GetStruct[profile](c, "user:1"); !found || err != nil || got != want {
+		t.Errorf("Expected stored struct, got %+v, %v, %v", got, found, err)
+	}
+	if _, err := scache.GetOrStore(c, "user:1", time.Minute, loader); err != nil || calls.Load() != 1 {
+		t.Errorf("Expected cache hit without loading, calls %d, err %v", calls.Load(), err)
+	}
+	if ttl, ok := c.TTL("user:1"); !ok || ttl <= 0 || ttl > time.Minute {
+		t.Errorf("Expected TTL within a minute, got %v", ttl)
+	}
+}
+
+func TestGetOrStoreCoalescesNormalizedKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.KeyNormalizer = strings.ToLower
+	c := scache.New(cfg)
+	defer c.Close()
+
+	var calls atomic.Int32
+	loader := func() (profile, error) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return profile{Name: "alice"}, nil
+	}
+
+	// 大小写不同的键指向同一条目，并发调用只加载一次
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for _, key := range []string{"User:1", "USER:1", "user:1", "uSeR:1"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			<-start
+			if got, err := scache.GetOrStore(c, key, time.Minute, loader); err != nil || got.Name != "alice" {
+				t.Errorf("GetOrStore(%s) = %+v, %v", key, got, err)
+			}
+		}(key)
+	}
+	close(start)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected loader to run once across key spellings, ran %d times", n)
+	}
+}
+
+func TestGetOrStorePrimitive(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := scache.GetOrStore(c, "answer", 0, func() (int, error) {
+				calls.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				return 42, nil
+			})
+			if err != nil || got != 42 {
+				t.Errorf("GetOrStore = %d, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected loader to run once, ran %d times", n)
+	}
+}
+
+func TestGetOrStoreLoaderErrorIsNotCached(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	errLoad := errors.New("backend down")
+	if _, err := scache.GetOrStore(c, "k", 0, func() (string, error) { return "", errLoad }); !errors.Is(err, errLoad) {
+		t.Fatalf("Expected loader error, got %v", err)
+	}
+	if c.Exists("k") {
+		t.Fatal("Failed load must not be stored")
+	}
+
+	got, err := scache.GetOrStore(c, "k", 0, func() (string, error) { return "ok", nil })
+	if err != nil || got != "ok" {
+		t.Errorf("Expected retry to load, got %q, %v", got, err)
+	}
+
+	// 已有值无法解码为请求的类型时返回错误
+	if _, err := scache.GetOrStore(c, "k", 0, func() (int, error) { return 1, nil }); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+func TestGetOrStoreLoaderTimeout(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.LoaderTimeout = 30 * time.Millisecond
+	c := scache.New(cfg)
+	defer c.Close()
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	hanging := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 1, nil
+	}
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := scache.GetOrStore(c, "slow", 0, hanging); !errors.Is(err, scache.ErrLoaderTimeout) {
+				t.Errorf("Expected ErrLoaderTimeout, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Waiters should give up after the loader timeout, took %v", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Concurrent waiters should share one loader call, got %d", n)
+	}
+	if c.Exists("slow") {
+		t.Error("Timed out load must not store a value")
+	}
+
+	// 超时后再次调用重新加载
+	if got, err := scache.GetOrStore(c, "slow", 0, func() (int, error) { return 2, nil }); err != nil || got != 2 {
+		t.Fatalf("Retry after timeout = %d, %v; want 2", got, err)
+	}
+
+	// 超时的loader最终返回时结果被丢弃
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	if got, _, _ := cache.GetStruct[int](c, "slow"); got != 2 {
+		t.Errorf("Late loader result should be discarded, got %d", got)
+	}
+}
+
+func TestLoaderTimeoutValidation(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.LoaderTimeout = -time.Second
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for negative loader timeout")
+	}
+}
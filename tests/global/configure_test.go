@@ -0,0 +1,40 @@
+// Package global 在独立进程中测试全局缓存初始化（全局实例只能初始化一次）
+package global
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestConfigureGlobal(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cfg.BackgroundCleanupInterval = time.Minute
+
+	// 首次使用前配置成功
+	if err := scache.ConfigureGlobal(cfg); err != nil {
+		t.Fatalf("ConfigureGlobal before first use failed: %v", err)
+	}
+
+	if scache.GetGlobalCache().GetEngine().(interface {
+		GetConfig() *config.EngineConfig
+	}).GetConfig().MaxSize != 2 {
+		t.Error("Global cache should use the custom config")
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		scache.SetString(key, "v")
+	}
+	if scache.Size() != 2 {
+		t.Errorf("Expected custom MaxSize 2 to apply, got size %d", scache.Size())
+	}
+
+	// 初始化后再次配置返回错误
+	if err := scache.ConfigureGlobal(config.DefaultEngineConfig()); !errors.Is(err, scache.ErrGlobalInitialized) {
+		t.Errorf("Expected ErrGlobalInitialized after first use, got %v", err)
+	}
+}
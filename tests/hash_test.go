@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestHashCommands(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetHash("user:1", map[string]interface{}{"name": "alice", "age": 30, "city": "paris"})
+
+	if all, ok := c.HGetAll("user:1"); !ok || len(all) != 3 || all["name"] != "alice" {
+		t.Errorf("HGetAll = %v, %v", all, ok)
+	}
+	if keys, _ := c.HKeys("user:1"); !reflect.DeepEqual(keys, []string{"age", "city", "name"}) {
+		t.Errorf("HKeys = %v", keys)
+	}
+	if vals, _ := c.HVals("user:1"); !reflect.DeepEqual(vals, []interface{}{30, "paris", "alice"}) {
+		t.Errorf("HVals = %v", vals)
+	}
+	if n := c.HLen("user:1"); n != 3 {
+		t.Errorf("HLen = %d, want 3", n)
+	}
+	if !c.HExists("user:1", "city") || c.HExists("user:1", "zip") {
+		t.Error("HExists reported wrong field presence")
+	}
+
+	if n := c.HDelMany("user:1", "age", "zip"); n != 1 {
+		t.Errorf("HDelMany should count only removed fields, got %d", n)
+	}
+	if n := c.HLen("user:1"); n != 2 {
+		t.Errorf("HLen after delete = %d, want 2", n)
+	}
+}
+
+func TestHashMissingAndWrongType(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("str", "v")
+	for _, key := range []string{"missing", "str"} {
+		if _, ok := c.HGetAll(key); ok {
+			t.Errorf("HGetAll(%q) should miss", key)
+		}
+		if _, ok := c.HKeys(key); ok {
+			t.Errorf("HKeys(%q) should miss", key)
+		}
+		if _, ok := c.HVals(key); ok {
+			t.Errorf("HVals(%q) should miss", key)
+		}
+		if c.HLen(key) != 0 || c.HExists(key, "f") || c.HDelMany(key, "f") != 0 {
+			t.Errorf("Hash reads on %q should report empty", key)
+		}
+	}
+}
+
+func TestHDelRemovesEmptyHash(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.HSet("h", "a", 1)
+	c.HSet("h", "b", 2)
+
+	if !c.HDel("h", "a") || !c.Exists("h") {
+		t.Fatal("Hash with remaining fields must be kept")
+	}
+	if !c.HDel("h", "b") {
+		t.Fatal("HDel of last field should succeed")
+	}
+	if c.Exists("h") || c.Size() != 0 {
+		t.Error("Deleting the last field should remove the key")
+	}
+	if stats := c.Stats().(map[string]interface{}); stats["memory"] != int64(0) {
+		t.Errorf("Memory usage should return to zero, got %v", stats["memory"])
+	}
+}
+
+func TestHSetConcurrentCreateKeepsAllFields(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	for trial := 0; trial < 200; trial++ {
+		key := fmt.Sprintf("h:%d", trial)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				c.HSet(key, fmt.Sprintf("f%d", i), i)
+			}(i)
+		}
+		wg.Wait()
+		if n := c.HLen(key); n != 8 {
+			t.Fatalf("Trial %d: concurrent HSet on a new key kept %d of 8 fields", trial, n)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestHotKeysFindsMostAccessedKey(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.AccessLogSize = 256
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	for i := 0; i < 10; i++ {
+		c.SetString(fmt.Sprintf("cold:%d", i), "v")
+	}
+	c.SetString("hot", "v")
+
+	for i := 0; i < 10; i++ {
+		c.GetString(fmt.Sprintf("cold:%d", i))
+		for j := 0; j < 5; j++ {
+			c.GetString("hot")
+		}
+	}
+
+	hot := c.HotKeys(time.Minute, 3)
+	if len(hot) != 3 {
+		t.Fatalf("HotKeys returned %d keys, want 3", len(hot))
+	}
+	if hot[0].Key != "hot" || hot[0].Accesses != 50 {
+		t.Errorf("Top hot key = %+v, want hot with 50 accesses", hot[0])
+	}
+	if hot[0].LastAccess.IsZero() {
+		t.Error("Expected LastAccess to be set")
+	}
+
+	// 窗口外的访问不计入
+	if got := c.HotKeys(time.Nanosecond, 0); len(got) != 0 {
+		t.Errorf("Expected no hot keys in an empty window, got %v", got)
+	}
+}
+
+func TestHotKeysSamplingAndRingSize(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.AccessLogSize = 8
+	cfg.AccessLogSampleEvery = 4
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	for i := 0; i < 100; i++ {
+		c.GetString("hot")
+	}
+
+	// 每4次采样一次共25条，但环形缓冲区只保留最近8条
+	hot := c.HotKeys(time.Minute, 1)
+	if len(hot) != 1 || hot[0].Key != "hot" || hot[0].Accesses != 8 {
+		t.Errorf("HotKeys = %+v, want hot with 8 sampled accesses", hot)
+	}
+
+	disabled := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer disabled.Close()
+	disabled.GetString("hot")
+	if got := disabled.HotKeys(time.Minute, 1); got != nil {
+		t.Errorf("Expected nil HotKeys without an access log, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestHashIndexTracksFieldUpdates(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetHash("user:1", map[string]interface{}{"city": "paris"})
+	cache.SetHash("user:2", map[string]interface{}{"city": "paris"})
+	cache.SetHash("user:3", map[string]interface{}{"city": "tokyo"})
+	cache.SetHash("order:1", map[string]interface{}{"city": "paris"}) // 不匹配模式
+
+	if err := cache.CreateIndex("user:*", "city"); err != nil {
+		t.Fatalf("CreateIndex failed: %v", err)
+	}
+
+	if keys := cache.LookupIndex("city", "paris"); strings.Join(keys, ",") != "user:1,user:2" {
+		t.Errorf("Expected [user:1 user:2] in paris, got %v", keys)
+	}
+
+	// 修改字段后键应移动到新桶
+	cache.HSet("user:2", "city", "tokyo")
+	if keys := cache.LookupIndex("city", "paris"); strings.Join(keys, ",") != "user:1" {
+		t.Errorf("Expected [user:1] in paris after update, got %v", keys)
+	}
+	if keys := cache.LookupIndex("city", "tokyo"); strings.Join(keys, ",") != "user:2,user:3" {
+		t.Errorf("Expected [user:2 user:3] in tokyo after update, got %v", keys)
+	}
+
+	// 删除字段、整键替换和删除键都应更新索引
+	cache.HDel("user:3", "city")
+	cache.SetHash("user:1", map[string]interface{}{"city": "rome"})
+	cache.Delete("user:2")
+	cache.HSet("user:4", "city", "rome")
+
+	if keys := cache.LookupIndex("city", "tokyo"); len(keys) != 0 {
+		t.Errorf("Expected no keys in tokyo, got %v", keys)
+	}
+	if keys := cache.LookupIndex("city", "rome"); strings.Join(keys, ",") != "user:1,user:4" {
+		t.Errorf("Expected [user:1 user:4] in rome, got %v", keys)
+	}
+}
+
+func TestHashIndexRemovesExpiredKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = 10 * time.Millisecond
+	cache := scache.New(cfg)
+
+	if err := cache.CreateIndex("session:*", "user"); err != nil {
+		t.Fatalf("CreateIndex failed: %v", err)
+	}
+	cache.SetHash("session:a", map[string]interface{}{"user": 42}, 20*time.Millisecond)
+	cache.SetHash("session:b", map[string]interface{}{"user": 42})
+
+	if keys := cache.LookupIndex("user", 42); len(keys) != 2 {
+		t.Fatalf("Expected 2 sessions for user 42, got %v", keys)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if keys := cache.LookupIndex("user", 42); strings.Join(keys, ",") != "session:b" {
+		t.Errorf("Expired key should be removed from the index, got %v", keys)
+	}
+}
+
+func TestHashIndexRemovesEvictedKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cfg.BackgroundCleanupInterval = time.Minute
+	cache := scache.New(cfg)
+
+	cache.CreateIndex("*", "tag")
+	cache.SetHash("a", map[string]interface{}{"tag": "x"})
+	cache.SetHash("b", map[string]interface{}{"tag": "x"})
+	cache.SetHash("c", map[string]interface{}{"tag": "x"}) // 淘汰a
+
+	if keys := cache.LookupIndex("tag", "x"); strings.Join(keys, ",") != "b,c" {
+		t.Errorf("Evicted key should be removed from the index, got %v", keys)
+	}
+}
@@ -1,7 +1,10 @@
 package tests
 
 import (
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -204,6 +207,67 @@ func TestConcurrentExpiredDeletion(t *testing.T) {
 	wg.Wait()
 }
 
+func TestConcurrentFlush(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				key := fmt.Sprintf("key:%d:%d", w, i%100)
+				cache.SetString(key, "value-"+key)
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				// Flush不会回收读取方持有的对象，读到的值必须完整
+				key := fmt.Sprintf("key:%d:%d", w, i%100)
+				if v, ok := cache.GetString(key); ok && v != "value-"+key {
+					t.Errorf("Torn read for %s: %q", key, v)
+					return
+				}
+			}
+		}(w)
+	}
+
+	for i := 0; i < 200; i++ {
+		if err := cache.Flush(); err != nil {
+			t.Fatalf("Flush failed: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	// 所有写入结束后Flush，状态必须为空
+	cache.Flush()
+	if cache.Size() != 0 || len(cache.Keys()) != 0 {
+		t.Fatalf("Expected empty cache after Flush, got size %d", cache.Size())
+	}
+	if stats := cache.Stats().(map[string]interface{}); stats["keys"] != 0 || stats["memory"] != int64(0) {
+		t.Errorf("Expected empty stats after Flush, got keys=%v memory=%v", stats["keys"], stats["memory"])
+	}
+
+	// Flush返回之后开始的写入不会丢失
+	cache.SetString("after", "flush")
+	if v, ok := cache.GetString("after"); !ok || v != "flush" {
+		t.Errorf("Write after Flush was lost: %q, %v", v, ok)
+	}
+}
+
 // ==================== 容量与淘汰测试 ====================
 
 func TestMaxSizeLimit(t *testing.T) {
@@ -298,3 +362,376 @@ func TestStats(t *testing.T) {
 		t.Errorf("Expected 1 miss, got %d", stats["misses"])
 	}
 }
+
+func TestExpireTime(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	setAt := time.Now()
+	cache.SetString("expiretime_key", "value", 10*time.Second)
+	cache.SetString("permanent_key", "value")
+
+	expected := setAt.Add(10 * time.Second)
+	if got := cache.PExpireTime("expiretime_key"); got < expected.UnixMilli()-50 || got > expected.UnixMilli()+50 {
+		t.Errorf("Expected PEXPIRETIME near %d, got %d", expected.UnixMilli(), got)
+	}
+	if got := cache.ExpireTime("expiretime_key"); got < expected.Unix()-1 || got > expected.Unix()+1 {
+		t.Errorf("Expected EXPIRETIME near %d, got %d", expected.Unix(), got)
+	}
+
+	if got := cache.ExpireTime("permanent_key"); got != -1 {
+		t.Errorf("Expected -1 for permanent key, got %d", got)
+	}
+	if got := cache.PExpireTime("missing_key"); got != -2 {
+		t.Errorf("Expected -2 for missing key, got %d", got)
+	}
+}
+
+func TestPTTL(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetString("pttl_key", "value", 1500*time.Millisecond)
+
+	if pttl := cache.PTTL("pttl_key"); pttl < 1400 || pttl > 1500 {
+		t.Errorf("Expected PTTL near 1500, got %d", pttl)
+	}
+	if ttl, _ := cache.TTL("pttl_key"); int(ttl.Seconds()) != 1 {
+		t.Errorf("Expected TTL to truncate to 1 second, got %v", ttl)
+	}
+
+	if pttl := cache.PTTL("missing_key"); pttl != -2 {
+		t.Errorf("Expected -2 for missing key, got %d", pttl)
+	}
+
+	cache.SetString("permanent_key", "value")
+	if pttl := cache.PTTL("permanent_key"); pttl != -1 {
+		t.Errorf("Expected -1 for permanent key, got %d", pttl)
+	}
+}
+
+func TestMTTL(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetString("permanent", "v")
+	cache.SetString("expiring", "v", 90*time.Second)
+	cache.SetList("list", []interface{}{"a"}, 10*time.Second)
+	cache.SetString("expired", "v", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	got := cache.MTTL("permanent", "missing", "expiring", "expired", "list")
+	want := []int{-1, -2, 89, -2, 9}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d results, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("MTTL[%d] = %d, want %d (all: %v)", i, got[i], want[i], got)
+		}
+	}
+
+	if got := cache.MTTL(); len(got) != 0 {
+		t.Errorf("Expected empty result for no keys, got %v", got)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.IdleTimeout = 100 * time.Millisecond
+	cache := scache.New(cfg)
+
+	cache.SetString("idle_key", "value")
+
+	// 每50ms读取一次，闲置时间始终小于超时
+	for i := 0; i < 6; i++ {
+		time.Sleep(50 * time.Millisecond)
+		if _, found := cache.GetString("idle_key"); !found {
+			t.Fatalf("Key should survive while read every 50ms (read %d)", i)
+		}
+	}
+
+	// 停止读取后超过闲置超时应过期
+	time.Sleep(150 * time.Millisecond)
+	if _, found := cache.GetString("idle_key"); found {
+		t.Error("Key should expire 100ms after the last read")
+	}
+}
+
+func TestStatsConcurrentAccess(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	// 并发读写
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				key := string(rune('a' + n))
+				cache.SetString(key, "value", time.Minute)
+				cache.GetString(key)
+				cache.GetString("missing")
+			}
+		}(i)
+	}
+
+	// 同时反复读取统计信息（使用 go test -race 验证无数据竞争）
+	for i := 0; i < 200; i++ {
+		stats := cache.Stats().(map[string]interface{})
+		if stats["hits"].(int64) < 0 || stats["misses"].(int64) < 0 {
+			t.Fatal("Stats counters should never be negative")
+		}
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+func TestStatsCountersExactUnderConcurrency(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("hot", "value", time.Minute)
+
+	const workers, iterations = 16, 1000
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				cache.GetString("hot")
+				cache.GetString("missing")
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats().(map[string]interface{})
+	if stats["hits"].(int64) != workers*iterations {
+		t.Errorf("Expected %d hits, got %d", workers*iterations, stats["hits"])
+	}
+	if stats["misses"].(int64) != workers*iterations {
+		t.Errorf("Expected %d misses, got %d", workers*iterations, stats["misses"])
+	}
+	if rate := stats["hit_rate"].(float64); rate != 0.5 {
+		t.Errorf("Expected hit rate 0.5, got %f", rate)
+	}
+}
+
+func TestConcurrentAccessTracking(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.SetString("tracked", "value", time.Minute)
+
+	obj, _ := cache.GetEngine().Get("tracked")
+	tracker := obj.(interface {
+		AccessedAt() time.Time
+		AccessCount() int64
+	})
+	before := tracker.AccessCount()
+
+	const workers, iterations = 16, 500
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				cache.GetEngine().Get("tracked")
+				_ = tracker.AccessedAt()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := tracker.AccessCount() - before; got != workers*iterations {
+		t.Errorf("Expected %d recorded accesses, got %d", workers*iterations, got)
+	}
+	if time.Since(tracker.AccessedAt()) > time.Second {
+		t.Error("Last access time should be recent")
+	}
+}
+
+func TestStatsWindowHitRate(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.StatsWindow = 50 * time.Millisecond
+	cache := scache.New(cfg)
+	cache.SetString("hot", "value", time.Minute)
+
+	// 一阵未命中
+	for i := 0; i < 1000; i++ {
+		cache.GetString("missing")
+	}
+
+	// 之后超过两个窗口只有命中
+	deadline := time.Now().Add(3 * cfg.StatsWindow)
+	for time.Now().Before(deadline) {
+		cache.GetString("hot")
+		time.Sleep(time.Millisecond)
+	}
+
+	stats := cache.Stats().(map[string]interface{})
+	window := stats["window_hit_rate"].(float64)
+	lifetime := stats["hit_rate"].(float64)
+	if window != 1 {
+		t.Errorf("Expected windowed hit rate 1 after pure hits, got %f", window)
+	}
+	if lifetime >= window {
+		t.Errorf("Lifetime hit rate %f should lag windowed rate %f", lifetime, window)
+	}
+
+	// 未启用窗口时不输出
+	plain := scache.New(config.DefaultEngineConfig()).Stats().(map[string]interface{})
+	if _, ok := plain["window_hit_rate"]; ok {
+		t.Error("window_hit_rate should be absent when StatsWindow is 0")
+	}
+}
+
+func TestStatsWindowFollowsEngineClock(t *testing.T) {
+	base := time.Now()
+	var elapsed atomic.Int64
+	cfg := config.DefaultEngineConfig()
+	cfg.StatsWindow = time.Minute
+	cfg.Clock = func() time.Time { return base.Add(time.Duration(elapsed.Load())) }
+	cache := scache.New(cfg)
+	cache.SetString("hot", "value")
+
+	for i := 0; i < 100; i++ {
+		cache.GetString("missing")
+	}
+	cache.GetString("hot")
+
+	// 可控时钟推进两个窗口后，之前的未命中移出窗口，不需要真实等待
+	elapsed.Add(int64(2 * cfg.StatsWindow))
+	cache.GetString("hot")
+
+	stats := cache.Stats().(map[string]interface{})
+	if window := stats["window_hit_rate"].(float64); window != 1 {
+		t.Errorf("Expected windowed hit rate 1 after the clock moved two windows, got %f", window)
+	}
+	if lifetime := stats["hit_rate"].(float64); lifetime >= 0.5 {
+		t.Errorf("Lifetime hit rate should still count the misses, got %f", lifetime)
+	}
+}
+
+func TestMinTTLFloor(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MinTTL = time.Second
+	cache := scache.New(cfg)
+
+	cache.SetString("short", "value", 10*time.Millisecond)
+	ttl, _ := cache.TTL("short")
+	if ttl < 900*time.Millisecond || ttl > time.Second {
+		t.Errorf("Expected TTL raised to ~1s, got %v", ttl)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, found := cache.GetString("short"); !found {
+		t.Error("Key should outlive its requested 10ms TTL")
+	}
+
+	// 永久键不受影响
+	cache.SetString("permanent", "value")
+	if ttl, _ := cache.TTL("permanent"); ttl != -1 {
+		t.Errorf("Permanent key should stay permanent, got TTL %v", ttl)
+	}
+
+	// Expire同样应用下限
+	cache.Expire("permanent", time.Millisecond)
+	if ttl, _ := cache.TTL("permanent"); ttl < 900*time.Millisecond {
+		t.Errorf("Expire should raise TTL to the floor, got %v", ttl)
+	}
+}
+
+func TestMinTTLReject(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MinTTL = time.Second
+	cfg.RejectBelowMinTTL = true
+	cache := scache.New(cfg)
+
+	err := cache.SetString("short", "value", 10*time.Millisecond)
+	if !errors.Is(err, scache.ErrTTLBelowMinimum) {
+		t.Errorf("Expected ErrTTLBelowMinimum, got %v", err)
+	}
+	if cache.Exists("short") {
+		t.Error("Rejected key should not be stored")
+	}
+
+	if err := cache.SetString("long", "value", time.Minute); err != nil {
+		t.Errorf("TTL above the floor should be accepted: %v", err)
+	}
+	if cache.Expire("long", time.Millisecond) {
+		t.Error("Expire below the floor should be rejected")
+	}
+}
+
+func TestExpireMatching(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	cache.SetString("session:a", "1", time.Minute)
+	cache.SetString("session:b", "2")
+	cache.SetHash("session:c", map[string]interface{}{"user": "x"}, time.Second)
+	cache.SetString("user:a", "3", time.Minute)
+
+	if n := cache.ExpireMatching("session:*", time.Hour); n != 3 {
+		t.Errorf("Expected 3 keys updated, got %d", n)
+	}
+
+	for _, key := range []string{"session:a", "session:b", "session:c"} {
+		if ttl, _ := cache.TTL(key); ttl < 59*time.Minute {
+			t.Errorf("Key %s should have ~1h TTL, got %v", key, ttl)
+		}
+	}
+	if ttl, _ := cache.TTL("user:a"); ttl > time.Minute {
+		t.Errorf("Non-matching key should keep its TTL, got %v", ttl)
+	}
+
+	// ttl为0时设为永不过期
+	if n := cache.ExpireMatching("session:[ab]", 0); n != 2 {
+		t.Errorf("Expected 2 keys made permanent, got %d", n)
+	}
+	if ttl, _ := cache.TTL("session:a"); ttl != -1 {
+		t.Errorf("Expected permanent key, got TTL %v", ttl)
+	}
+}
+
+func TestRemovedObjectsAreNotRecycled(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 1
+	cfg.BackgroundCleanupInterval = time.Minute
+	cache := scache.New(cfg)
+	defer cache.Close()
+	engine := cache.GetEngine()
+
+	held := make(map[string]scache.DataObject)
+	remove := map[string]func(key string){
+		"delete":      func(key string) { engine.Delete(key) },
+		"delete_many": func(key string) { engine.DeleteMany([]string{key}) },
+		"evict":       func(key string) { cache.SetString("other", "x") },
+		"expire":      func(key string) { time.Sleep(5 * time.Millisecond); cache.Exists(key) },
+	}
+	for name, fn := range remove {
+		key := "held:" + name
+		ttl := time.Duration(0)
+		if name == "expire" {
+			ttl = time.Millisecond
+		}
+		cache.SetString(key, name, ttl)
+		obj, _ := engine.Get(key)
+		held[name] = obj
+		fn(key)
+		// 大量写入新对象，被回收的对象会在此时被复用
+		for i := 0; i < 100; i++ {
+			cache.SetString(fmt.Sprintf("churn:%d", i), "churn")
+		}
+	}
+
+	for name, obj := range held {
+		if v := obj.(scache.StringObject).Value(); v != name {
+			t.Errorf("%s: object held by a reader was reset or reused, value = %q", name, v)
+		}
+	}
+}
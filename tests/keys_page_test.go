@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestKeysPage(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig()).(*storage.StorageEngine)
+	for i := 0; i < 25; i++ {
+		engine.Set(fmt.Sprintf("key:%02d", i), types.NewStringObject("v", 0))
+	}
+
+	tests := []struct {
+		page    int
+		first   string
+		count   int
+		hasNext bool
+	}{
+		{1, "key:00", 10, true},
+		{2, "key:10", 10, true},
+		{3, "key:20", 5, false},
+	}
+
+	for _, tt := range tests {
+		result := engine.KeysPage(tt.page, 10)
+		if result.Total != 25 {
+			t.Errorf("page %d: expected total 25, got %d", tt.page, result.Total)
+		}
+		if result.Page != tt.page {
+			t.Errorf("page %d: expected page %d, got %d", tt.page, tt.page, result.Page)
+		}
+		if len(result.Keys) != tt.count {
+			t.Fatalf("page %d: expected %d keys, got %d", tt.page, tt.count, len(result.Keys))
+		}
+		if result.Keys[0] != tt.first {
+			t.Errorf("page %d: expected first key %s, got %s", tt.page, tt.first, result.Keys[0])
+		}
+		if result.HasNext != tt.hasNext {
+			t.Errorf("page %d: expected HasNext=%v, got %v", tt.page, tt.hasNext, result.HasNext)
+		}
+	}
+
+	if result := engine.KeysPage(4, 10); len(result.Keys) != 0 || result.HasNext {
+		t.Errorf("Page past the end should be empty, got %v", result)
+	}
+}
+
+func TestLocalCacheKeysPage(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	for i := 0; i < 25; i++ {
+		cache.SetString(fmt.Sprintf("key:%02d", i), "v")
+	}
+
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		result := cache.KeysPage(page, 10)
+		for _, key := range result.Keys {
+			if seen[key] {
+				t.Fatalf("Key %s returned on more than one page", key)
+			}
+			seen[key] = true
+		}
+		if !result.HasNext {
+			break
+		}
+	}
+	if len(seen) != 25 {
+		t.Errorf("Expected 25 distinct keys across pages, got %d", len(seen))
+	}
+}
+
+func TestKeysMatch(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	defer cache.Close()
+
+	for _, key := range []string{"user:2", "user:1", "user:10", "order:1", "userx"} {
+		cache.SetString(key, "v")
+	}
+	cache.SetString("user:expired", "v", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"user:*", []string{"user:1", "user:10", "user:2"}},
+		{"user:?", []string{"user:1", "user:2"}},
+		{"user:[12]", []string{"user:1", "user:2"}},
+		{"*:1", []string{"order:1", "user:1"}},
+		{"", []string{"order:1", "user:1", "user:10", "user:2", "userx"}},
+		{"nothing*", []string{}},
+	}
+	for _, tt := range tests {
+		if got := cache.KeysMatch(tt.pattern); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("KeysMatch(%q) = %v, expected %v", tt.pattern, got, tt.expected)
+		}
+	}
+
+	if got := cache.KeysMatch("user:["); got != nil {
+		t.Errorf("Malformed pattern should return nil, got %v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+// settledGoroutines 等待前面测试遗留的goroutine退出后返回当前goroutine数
+func settledGoroutines() int {
+	n := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		time.Sleep(2 * time.Millisecond)
+		next := runtime.NumGoroutine()
+		if next == n {
+			return n
+		}
+		n = next
+	}
+	return n
+}
+
+func TestZeroCleanupIntervalStartsNoGoroutine(t *testing.T) {
+	const engines = 10
+
+	newEngines := func(interval time.Duration) []interfaces.StorageEngine {
+		cfg := config.DefaultEngineConfig()
+		cfg.BackgroundCleanupInterval = interval
+		list := make([]interfaces.StorageEngine, engines)
+		for i := range list {
+			list[i] = storage.NewStorageEngine(cfg)
+		}
+		return list
+	}
+
+	before := settledGoroutines()
+	lazy := newEngines(0)
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("interval 0 spawned %d goroutines", after-before)
+	}
+
+	// 对照：正间隔时每个引擎启动一个清理goroutine
+	background := newEngines(time.Hour)
+	if after := runtime.NumGoroutine(); after < before+engines {
+		t.Errorf("Expected at least %d goroutines with cleanup enabled, got %d", before+engines, after)
+	}
+	for _, engine := range append(lazy, background...) {
+		engine.Close()
+	}
+}
+
+func TestZeroCleanupIntervalExpiresLazily(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+
+	engine.Set("k", types.NewStringObject("v", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// 没有后台清理，过期键在被访问前仍留在存储中
+	if engine.Size() != 1 {
+		t.Fatalf("Expected expired key to stay until accessed, size %d", engine.Size())
+	}
+	if _, ok := engine.Get("k"); ok {
+		t.Error("Expired key should not be returned")
+	}
+	if engine.Size() != 0 {
+		t.Errorf("Expected access to remove the expired key, size %d", engine.Size())
+	}
+}
+
+func TestConcurrentReadsOfExpiredKeyCountOneExpiration(t *testing.T) {
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+
+	for round := 0; round < 20; round++ {
+		engine.Set("k", types.NewStringObject("v", time.Millisecond))
+		time.Sleep(3 * time.Millisecond)
+
+		// 多个读取方同时发现同一个过期键，只有实际删除的一方计入过期统计
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 32; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				switch i % 5 {
+				case 0:
+					engine.Get("k")
+				case 1:
+					engine.Exists("k")
+				case 2:
+					engine.Type("k")
+				case 3:
+					engine.ExpireTime("k")
+				default:
+					engine.MGetTouch([]string{"k"})
+				}
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+	}
+
+	stats := engine.Stats().(map[string]interface{})
+	if got := stats["expirations"]; got != int64(20) {
+		t.Errorf("Expected exactly one expiration per removal (20), got %v", got)
+	}
+	if engine.Size() != 0 {
+		t.Errorf("Expired key should be removed, size %d", engine.Size())
+	}
+}
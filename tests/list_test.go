@@ -0,0 +1,313 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+// ==================== 列表索引测试 ====================
+
+func TestListRangeNegativeIndices(t *testing.T) {
+	list := scache.NewListObject([]interface{}{"a", "b", "c", "d", "e"}, time.Minute)
+
+	tests := []struct {
+		name       string
+		start, end int
+		expected   []interface{}
+	}{
+		{"full range", 0, -1, []interface{}{"a", "b", "c", "d", "e"}},
+		{"positive range", 1, 3, []interface{}{"b", "c", "d"}},
+		{"last two", -2, -1, []interface{}{"d", "e"}},
+		{"mixed signs", 1, -2, []interface{}{"b", "c", "d"}},
+		{"stop beyond length clamps", 3, 100, []interface{}{"d", "e"}},
+		{"start before head clamps", -100, 1, []interface{}{"a", "b"}},
+		{"start beyond length", 5, 10, []interface{}{}},
+		{"start after stop", 3, 1, []interface{}{}},
+		{"negative start after stop", -1, -2, []interface{}{}},
+		{"single element", -1, -1, []interface{}{"e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := list.Range(tt.start, tt.end)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Range(%d, %d) = %v, expected %v", tt.start, tt.end, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestListIndexNegative(t *testing.T) {
+	list := scache.NewListObject([]interface{}{"a", "b", "c"}, time.Minute)
+
+	tests := []struct {
+		index    int
+		expected interface{}
+		found    bool
+	}{
+		{0, "a", true},
+		{2, "c", true},
+		{-1, "c", true},
+		{-3, "a", true},
+		{3, nil, false},
+		{-4, nil, false},
+	}
+
+	for _, tt := range tests {
+		got, found := list.Index(tt.index)
+		if found != tt.found || got != tt.expected {
+			t.Errorf("Index(%d) = (%v, %v), expected (%v, %v)", tt.index, got, found, tt.expected, tt.found)
+		}
+	}
+}
+
+// ==================== 列表两端操作测试 ====================
+
+func TestListPushPop(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if n, err := c.RPush("queue", "a", "b"); n != 2 || err != nil {
+		t.Fatalf("RPush = %d, %v", n, err)
+	}
+	if n, _ := c.LPush("queue", "y", "z"); n != 4 {
+		t.Errorf("LPush length = %d, want 4", n)
+	}
+	if got := c.LRange("queue", 0, -1); !reflect.DeepEqual(got, []interface{}{"z", "y", "a", "b"}) {
+		t.Errorf("LRange = %v", got)
+	}
+	if c.LLen("queue") != 4 {
+		t.Errorf("LLen = %d, want 4", c.LLen("queue"))
+	}
+
+	if v, ok := c.LPop("queue"); !ok || v != "z" {
+		t.Errorf("LPop = %v, %v", v, ok)
+	}
+	if v, ok := c.RPop("queue"); !ok || v != "b" {
+		t.Errorf("RPop = %v, %v", v, ok)
+	}
+	c.LPop("queue")
+	c.LPop("queue")
+	if c.Exists("queue") {
+		t.Error("Popping the last element should delete the key")
+	}
+	if _, ok := c.LPop("queue"); ok {
+		t.Error("LPop on missing key should miss")
+	}
+}
+
+func TestListFIFOQueue(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	// 交替入队出队，头部空位被复用，顺序保持先进先出
+	next := 0
+	for i := 0; i < 1000; i++ {
+		c.RPush("q", i)
+		if i%3 == 2 {
+			v, _ := c.LPop("q")
+			if v != next {
+				t.Fatalf("LPop = %v, want %d", v, next)
+			}
+			next++
+		}
+	}
+	if c.LLen("q") != 1000-next {
+		t.Errorf("LLen = %d, want %d", c.LLen("q"), 1000-next)
+	}
+	if got := c.LRange("q", 0, 0); !reflect.DeepEqual(got, []interface{}{next}) {
+		t.Errorf("Head = %v, want %d", got, next)
+	}
+}
+
+func TestListRangeEmptyAndWrongType(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if got := c.LRange("missing", 0, -1); got == nil || len(got) != 0 {
+		t.Errorf("LRange on missing key = %#v, want empty slice", got)
+	}
+	c.SetList("empty", []interface{}{})
+	if got := c.LRange("empty", 0, -1); got == nil || len(got) != 0 {
+		t.Errorf("LRange on empty list = %#v, want empty slice", got)
+	}
+	if got := c.LRange("empty", 5, 10); got == nil {
+		t.Error("LRange out of range should return empty slice, not nil")
+	}
+
+	c.SetString("str", "v")
+	if _, err := c.RPush("str", "a"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("RPush on string key: got %v", err)
+	}
+	if _, ok := c.LPop("str"); ok {
+		t.Error("LPop on string key should miss")
+	}
+	if c.LLen("str") != 0 {
+		t.Error("LLen on string key should be 0")
+	}
+	if _, err := c.RPush("list"); !errors.Is(err, scache.ErrInvalidArgument) {
+		t.Errorf("RPush without values: got %v", err)
+	}
+}
+
+func TestListPushFrontOnCircularList(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.RPushCap("ring", 3, "a")
+	c.LPush("ring", "b", "c", "d")
+	if got := c.LRange("ring", 0, -1); !reflect.DeepEqual(got, []interface{}{"d", "c", "b"}) {
+		t.Errorf("LPush on ring should respect capacity, got %v", got)
+	}
+}
+
+func TestListObjectPushFrontPopFront(t *testing.T) {
+	list := scache.NewListObject([]interface{}{"b"}, 0)
+	list.PushFront("a")
+	list.Push("c")
+	for i := 0; i < 20; i++ {
+		list.PushFront(i)
+	}
+	for i := 19; i >= 0; i-- {
+		if v, _ := list.PopFront(); v != i {
+			t.Fatalf("PopFront = %v, want %d", v, i)
+		}
+	}
+	if got := list.Values(); !reflect.DeepEqual(got, []interface{}{"a", "b", "c"}) {
+		t.Errorf("Values = %v", got)
+	}
+}
+
+func TestLMove(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.RPush("queue", "a", "b", "c")
+	if v, err := c.LMove("queue", "processing", scache.ListLeft, scache.ListRight); err != nil || v != "a" {
+		t.Fatalf("LMove = %v, %v; want a", v, err)
+	}
+	if v, _ := c.LMove("queue", "processing", scache.ListRight, scache.ListLeft); v != "c" {
+		t.Fatalf("LMove from tail = %v, want c", v)
+	}
+	if got := c.LRange("processing", 0, -1); !reflect.DeepEqual(got, []interface{}{"c", "a"}) {
+		t.Errorf("processing = %v", got)
+	}
+
+	// 源与目标相同时在列表内轮转，单元素列表不会被删除
+	c.LMove("processing", "processing", scache.ListLeft, scache.ListRight)
+	if got := c.LRange("processing", 0, -1); !reflect.DeepEqual(got, []interface{}{"a", "c"}) {
+		t.Errorf("Rotated list = %v", got)
+	}
+	c.LMove("queue", "queue", scache.ListLeft, scache.ListRight)
+	if got := c.LRange("queue", 0, -1); !reflect.DeepEqual(got, []interface{}{"b"}) {
+		t.Errorf("Single-element rotation = %v", got)
+	}
+
+	// 取出最后一个元素时删除源键
+	c.LMove("queue", "processing", scache.ListLeft, scache.ListLeft)
+	if c.Exists("queue") || c.LLen("processing") != 3 {
+		t.Errorf("Moving the last element should delete the source, processing len=%d", c.LLen("processing"))
+	}
+}
+
+func TestLMoveEmptySourceAndWrongType(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if v, err := c.LMove("missing", "dst", scache.ListLeft, scache.ListRight); v != nil || err != nil {
+		t.Errorf("Empty source should return nil, nil; got %v, %v", v, err)
+	}
+	if c.Exists("dst") {
+		t.Error("Empty source must not create the destination")
+	}
+
+	c.RPush("src", "a")
+	c.SetString("str", "v")
+	if _, err := c.LMove("src", "str", scache.ListLeft, scache.ListRight); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for non-list destination, got %v", err)
+	}
+	if _, err := c.LMove("str", "src", scache.ListLeft, scache.ListRight); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for non-list source, got %v", err)
+	}
+	if got := c.LRange("src", 0, -1); !reflect.DeepEqual(got, []interface{}{"a"}) {
+		t.Errorf("Failed LMove must leave the source untouched, got %v", got)
+	}
+}
+
+func TestLMoveAtomic(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	const total = 100
+	for i := 0; i < total; i++ {
+		c.RPush("a", i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			src, dst := "a", "b"
+			if g%2 == 1 {
+				src, dst = dst, src
+			}
+			for i := 0; i < 500; i++ {
+				c.LMove(src, dst, scache.ListLeft, scache.ListRight)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// 每个元素恰好出现在其中一个列表中一次
+	seen := make(map[interface{}]int)
+	for _, key := range []string{"a", "b"} {
+		for _, v := range c.LRange(key, 0, -1) {
+			seen[v]++
+		}
+	}
+	if len(seen) != total {
+		t.Fatalf("Expected %d distinct elements, got %d", total, len(seen))
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Errorf("Element %v appears %d times", v, n)
+		}
+	}
+}
+
+func TestListPushPopMemoryAccounting(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	c := scache.New(cfg)
+	defer c.Close()
+
+	for i := 0; i < 1000; i++ {
+		c.RPush("list", i, "value")
+		c.LPush("list", i)
+	}
+	for i := 0; i < 500; i++ {
+		c.LPop("list")
+		c.RPop("list")
+	}
+	c.RPushCap("ring", 3, "a")
+	c.RPush("ring", "bb", "ccc", "dddd", 12345)
+	c.LPush("ring", "e")
+	c.LMove("ring", "list", scache.ListRight, scache.ListLeft)
+
+	want := 0
+	for _, key := range []string{"list", "ring"} {
+		data, _ := json.Marshal(c.LRange(key, 0, -1))
+		want += len(data)
+	}
+	if got := c.Stats().(map[string]interface{})["memory"]; got != int64(want) {
+		t.Errorf("Memory usage = %v, want %d", got, want)
+	}
+}
@@ -0,0 +1,366 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/pkg/memo"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int64
+	square := memo.Memoize(func(n int) int {
+		calls.Add(1)
+		return n * n
+	})
+
+	for i := 0; i < 3; i++ {
+		if got := square(4); got != 16 {
+			t.Fatalf("Expected 16, got %d", got)
+		}
+	}
+	if got := square(5); got != 25 {
+		t.Fatalf("Expected 25, got %d", got)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 underlying calls, got %d", calls.Load())
+	}
+}
+
+func TestMemoizeTTLRecomputes(t *testing.T) {
+	var calls atomic.Int64
+	lookup := memo.Memoize(func(name string) string {
+		calls.Add(1)
+		return "hello " + name
+	}, memo.WithTTL(30*time.Millisecond))
+
+	lookup("bob")
+	lookup("bob")
+	if calls.Load() != 1 {
+		t.Fatalf("Expected 1 call before TTL, got %d", calls.Load())
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := lookup("bob"); got != "hello bob" {
+		t.Errorf("Expected recomputed value, got %q", got)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected recomputation after TTL, got %d calls", calls.Load())
+	}
+}
+
+func TestMemoizeSingleFlight(t *testing.T) {
+	var calls atomic.Int64
+	slow := memo.Memoize(func(n int) int {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return n + 1
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := slow(1); got != 2 {
+				t.Errorf("Expected 2, got %d", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Concurrent calls should be deduplicated, got %d invocations", calls.Load())
+	}
+}
+
+func TestMemoize2(t *testing.T) {
+	type result struct {
+		Sum int
+		Tag string
+	}
+
+	var calls atomic.Int64
+	add := memo.Memoize2(func(a int, tag string) result {
+		calls.Add(1)
+		return result{Sum: a * 2, Tag: tag}
+	})
+
+	add(1, "x")
+	add(1, "x")
+	if got := add(1, "y"); got.Tag != "y" || got.Sum != 2 {
+		t.Errorf("Unexpected result %+v", got)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 calls for 2 distinct argument pairs, got %d", calls.Load())
+	}
+}
+
+func TestMemoizeCoalescingStats(t *testing.T) {
+	var stats memo.Stats
+	slow := memo.Memoize(func(n int) int {
+		time.Sleep(50 * time.Millisecond)
+		return n
+	}, memo.WithStats(&stats))
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			slow(7)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if stats.Loads() != 1 {
+		t.Errorf("Expected 1 load, got %d", stats.Loads())
+	}
+	if stats.TotalLoadRequests() != 50 {
+		t.Errorf("Expected 50 load requests, got %d", stats.TotalLoadRequests())
+	}
+	if stats.CoalescedLoads() != 49 {
+		t.Errorf("Expected 49 coalesced loads, got %d", stats.CoalescedLoads())
+	}
+	if ratio := stats.CoalescingRatio(); ratio != 0.98 {
+		t.Errorf("Expected coalescing ratio 0.98, got %f", ratio)
+	}
+
+	snapshot := stats.Snapshot()
+	if snapshot["coalesced_loads"].(int64) != 49 || snapshot["total_load_requests"].(int64) != 50 {
+		t.Errorf("Unexpected snapshot %v", snapshot)
+	}
+
+	// 命中缓存的调用不计入加载请求
+	slow(7)
+	if stats.TotalLoadRequests() != 50 {
+		t.Errorf("Cache hits should not count as load requests, got %d", stats.TotalLoadRequests())
+	}
+}
+
+func TestMemoizeLimiterBoundsConcurrentLoads(t *testing.T) {
+	var running, peak atomic.Int64
+	limiter := memo.NewLimiter(4, false)
+	load := memo.Memoize(func(n int) int {
+		cur := running.Add(1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		running.Add(-1)
+		return n
+	}, memo.WithLimiter(limiter))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if got := load(n); got != n {
+				t.Errorf("Expected %d, got %d", n, got)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 4 || p < 1 {
+		t.Errorf("Expected at most 4 concurrent loads, peak was %d", p)
+	}
+	if limiter.InFlight() != 0 {
+		t.Errorf("Expected all slots released, %d in flight", limiter.InFlight())
+	}
+}
+
+func TestMemoizeELoadShed(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	limiter := memo.NewLimiter(2, true)
+	stats := &memo.Stats{}
+	load := memo.MemoizeE(func(n int) (int, error) {
+		started <- struct{}{}
+		<-release
+		return n, nil
+	}, memo.WithLimiter(limiter), memo.WithStats(stats))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			load(n)
+		}(i)
+	}
+	<-started
+	<-started
+
+	// 两个名额都被占用时，其他键的加载立即失败
+	if _, err := load(99); !errors.Is(err, scache.ErrLoadShed) {
+		t.Errorf("Expected ErrLoadShed, got %v", err)
+	}
+	close(release)
+	wg.Wait()
+
+	// 失败的加载不缓存，名额释放后可以重试
+	if v, err := load(99); err != nil || v != 99 {
+		t.Errorf("Expected retry to succeed, got %d, %v", v, err)
+	}
+	if stats.Loads() != 3 || stats.CoalescedLoads() != 0 {
+		t.Errorf("Expected 3 loads and no coalesced loads, got %v", stats.Snapshot())
+	}
+}
+
+func TestMemoizeEDoesNotCacheErrors(t *testing.T) {
+	var calls atomic.Int64
+	load := memo.MemoizeE(func(key string) (string, error) {
+		if calls.Add(1) == 1 {
+			return "", errors.New("backend unavailable")
+		}
+		return "value:" + key, nil
+	})
+
+	if _, err := load("k"); err == nil {
+		t.Fatal("Expected the first load to fail")
+	}
+	if v, err := load("k"); err != nil || v != "value:k" {
+		t.Fatalf("Expected retry to succeed, got %q, %v", v, err)
+	}
+	if v, _ := load("k"); v != "value:k" || calls.Load() != 2 {
+		t.Errorf("Expected cached value after success, got %q with %d calls", v, calls.Load())
+	}
+}
+
+func TestMemoizeKeepsUnexportedFields(t *testing.T) {
+	type point struct {
+		x, y int
+	}
+
+	var calls atomic.Int64
+	locate := memo.Memoize(func(n int) point {
+		calls.Add(1)
+		return point{x: n, y: n * 2}
+	})
+
+	for i := 0; i < 3; i++ {
+		if got := locate(3); got != (point{x: 3, y: 6}) {
+			t.Fatalf("Expected {3 6} on call %d, got %+v", i, got)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestMemoizeDistinguishesArgumentTypes(t *testing.T) {
+	describe := func(v any) string {
+		switch v.(type) {
+		case int:
+			return "int"
+		case int64:
+			return "int64"
+		}
+		return "other"
+	}
+
+	inMemory := memo.Memoize[any, string](describe)
+	shared := memo.Memoize[any, string](describe, memo.WithCache(scache.New(scache.DefaultEngineConfig())))
+	for _, fn := range []func(any) string{inMemory, shared} {
+		if got := fn(1); got != "int" {
+			t.Errorf("Expected int, got %q", got)
+		}
+		if got := fn(int64(1)); got != "int64" {
+			t.Errorf("Expected int64, got %q", got)
+		}
+	}
+}
+
+func TestMemoizePanicDoesNotPoisonWaiters(t *testing.T) {
+	var calls atomic.Int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := memo.Memoize(func(n int) int {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			panic("backend exploded")
+		}
+		return n
+	})
+
+	leaderDone := make(chan any)
+	go func() {
+		defer func() { leaderDone <- recover() }()
+		load(1)
+	}()
+	<-started
+
+	waiterDone := make(chan int)
+	go func() { waiterDone <- load(1) }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if r := <-leaderDone; r != "backend exploded" {
+		t.Errorf("Expected the panic in the loading call, got %v", r)
+	}
+	// 等待者重新加载而不是得到零值或panic
+	if got := <-waiterDone; got != 1 {
+		t.Errorf("Expected waiter to reload 1, got %d", got)
+	}
+}
+
+func TestMemoizeEPanicReturnsErrorToWaiters(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := memo.MemoizeE(func(n int) (int, error) {
+		close(started)
+		<-release
+		panic("backend exploded")
+	})
+
+	go func() {
+		defer func() { recover() }()
+		load(1)
+	}()
+	<-started
+
+	errc := make(chan error)
+	go func() {
+		_, err := load(1)
+		errc <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-errc; !errors.Is(err, scache.ErrLoaderPanicked) {
+		t.Errorf("Expected ErrLoaderPanicked, got %v", err)
+	}
+}
+
+func TestMemoizeWithCacheReportsStoreErrors(t *testing.T) {
+	type handler struct {
+		Fn func()
+	}
+
+	var failed atomic.Int64
+	load := memo.Memoize(func(n int) handler {
+		return handler{Fn: func() {}}
+	}, memo.WithCache(scache.New(scache.DefaultEngineConfig())), memo.WithOnStoreError(func(key string, err error) {
+		failed.Add(1)
+	}))
+
+	if got := load(1); got.Fn == nil {
+		t.Error("Expected the computed value even when it cannot be stored")
+	}
+	if failed.Load() != 1 {
+		t.Errorf("Expected 1 reported store error, got %d", failed.Load())
+	}
+}
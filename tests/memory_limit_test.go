@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+)
+
+func memoryUsage(c *scache.LocalCache) int64 {
+	return c.Stats().(map[string]interface{})["memory"].(int64)
+}
+
+func TestMaxMemoryBytesEvictsLeastRecentlyUsed(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxMemoryBytes = 1000
+	cfg.BackgroundCleanupInterval = time.Minute // 避免严格模式按MemoryThreshold检查系统内存
+	cfg.MemoryThreshold = 0.5                   // 超过500字节时淘汰
+	c := scache.New(cfg)
+	defer c.Close()
+
+	value := strings.Repeat("x", 100)
+	for i := 0; i < 5; i++ {
+		c.SetString(fmt.Sprintf("k%d", i), value)
+	}
+	if c.Size() != 5 || memoryUsage(c) != 500 {
+		t.Fatalf("Expected 5 keys using 500 bytes, got %d keys, %d bytes", c.Size(), memoryUsage(c))
+	}
+
+	c.GetString("k0") // k0变为最近使用，k1成为最久未使用
+	c.SetString("k5", value)
+
+	if c.Exists("k1") {
+		t.Error("Least recently used key should be evicted once memory exceeds the limit")
+	}
+	if !c.Exists("k0") || !c.Exists("k5") {
+		t.Error("Recently used and newly written keys should be kept")
+	}
+	if got := memoryUsage(c); got > 500 {
+		t.Errorf("Memory usage should be back under the limit, got %d", got)
+	}
+
+	stats := c.Stats().(map[string]interface{})
+	if stats["evictions"] != int64(1) || stats["memory_limit"] != int64(500) {
+		t.Errorf("Unexpected stats: evictions=%v memory_limit=%v", stats["evictions"], stats["memory_limit"])
+	}
+}
+
+func TestMaxMemoryBytesEvictsInLoopForLargeValue(t *testing.T) {
+	var evicted []string
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxMemoryBytes = 1000
+	cfg.MemoryThreshold = 1
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.OnEvict = func(key string, obj interfaces.DataObject) {
+		evicted = append(evicted, key)
+	}
+	c := scache.New(cfg)
+	defer c.Close()
+
+	for i := 0; i < 10; i++ {
+		c.SetString(fmt.Sprintf("k%d", i), strings.Repeat("x", 100))
+	}
+	c.SetString("big", strings.Repeat("y", 450))
+
+	if len(evicted) != 5 || evicted[0] != "k0" || evicted[4] != "k4" {
+		t.Errorf("Expected k0..k4 evicted in LRU order, got %v", evicted)
+	}
+	if got := memoryUsage(c); got != 950 {
+		t.Errorf("Memory usage = %d, want 950", got)
+	}
+}
+
+func TestMaxMemoryBytesTracksReplacedObjects(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxMemoryBytes = 1000
+	cfg.BackgroundCleanupInterval = time.Minute
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.SetList("l", []interface{}{"aa", "bb"})
+	before := memoryUsage(c)
+	c.SetList("l", []interface{}{"aa", "bb", "cc", "dd"})
+	if after := memoryUsage(c); after <= before {
+		t.Errorf("Replacing a list with a larger one should grow memory usage: %d -> %d", before, after)
+	}
+	c.SetHash("l", map[string]interface{}{"f": 1})
+	c.Delete("l")
+	if got := memoryUsage(c); got != 0 {
+		t.Errorf("Memory usage should return to zero, got %d", got)
+	}
+}
+
+func TestMaxMemoryBytesValidation(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxMemoryBytes = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for negative max memory bytes")
+	}
+}
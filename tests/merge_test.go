@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestEngineDiff(t *testing.T) {
+	base := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer base.Close()
+	other := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer other.Close()
+
+	base.Set("same", types.NewStringObject("v", 0))
+	base.Set("changed", types.NewStringObject("old", 0))
+	base.Set("retyped", types.NewStringObject("v", 0))
+	base.Set("removed", types.NewStringObject("v", 0))
+
+	other.Set("same", types.NewStringObject("v", time.Hour))
+	other.Set("changed", types.NewStringObject("new", 0))
+	other.Set("retyped", types.NewListObject([]interface{}{"v"}, 0))
+	other.Set("added", types.NewStringObject("v", 0))
+	other.Set("expired", types.NewStringObject("v", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	added, changed, removed := base.Diff(other)
+	if !reflect.DeepEqual(added, []string{"added"}) {
+		t.Errorf("added = %v, want [added]", added)
+	}
+	if !reflect.DeepEqual(changed, []string{"changed", "retyped"}) {
+		t.Errorf("changed = %v, want [changed retyped]", changed)
+	}
+	if !reflect.DeepEqual(removed, []string{"removed"}) {
+		t.Errorf("removed = %v, want [removed]", removed)
+	}
+}
+
+func TestEngineMergeNewestWins(t *testing.T) {
+	dst := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer dst.Close()
+	src := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer src.Close()
+
+	src.Set("stale", types.NewStringObject("src", 0))
+	dst.Set("fresh", types.NewStringObject("dst", 0))
+	time.Sleep(2 * time.Millisecond)
+	dst.Set("stale", types.NewStringObject("dst", 0))
+	src.Set("fresh", types.NewStringObject("src", 0))
+	src.Set("only-src", types.NewHashObject(map[string]interface{}{"f": "v"}, 0))
+
+	merged, err := dst.Merge(src, interfaces.MergeNewestWins)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if merged != 2 {
+		t.Errorf("merged = %d, want 2", merged)
+	}
+
+	want := map[string]string{"stale": "dst", "fresh": "src"}
+	for key, value := range want {
+		obj, ok := dst.Get(key)
+		if !ok {
+			t.Fatalf("key %q missing after merge", key)
+		}
+		if got := obj.(*types.StringObject).Value(); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if typ, ok := dst.Type("only-src"); !ok || typ != interfaces.DataTypeHash {
+		t.Errorf("only-src type = %v, %v; want hash", typ, ok)
+	}
+
+	// 合并后的对象是副本，修改来源不影响目标
+	src.HSet("only-src", "f", "changed")
+	if obj, _ := dst.Get("only-src"); obj.(*types.HashObject).Fields()["f"] != "v" {
+		t.Error("merged object must not be shared with the source engine")
+	}
+
+	if added, changed, _ := src.Diff(dst); len(added) != 0 || !reflect.DeepEqual(changed, []string{"only-src", "stale"}) {
+		t.Errorf("Diff after merge = %v, %v", added, changed)
+	}
+}
+
+func TestEngineMergeNewestWinsUsesLastModification(t *testing.T) {
+	dst := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer dst.Close()
+	src := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer src.Close()
+
+	dst.Set("hash", types.NewHashObject(map[string]interface{}{"f": "dst"}, 0))
+	dst.Set("list", types.NewListObject([]interface{}{"dst"}, 0))
+	time.Sleep(2 * time.Millisecond)
+	src.Set("hash", types.NewHashObject(map[string]interface{}{"f": "src"}, 0))
+	src.Set("list", types.NewListObject([]interface{}{"src"}, 0))
+	time.Sleep(2 * time.Millisecond)
+
+	// 目标中的键创建得更早，但在来源写入之后被原地修改过
+	dst.HSet("hash", "g", "dst")
+	dst.RPush("list", "dst")
+
+	merged, err := dst.Merge(src, interfaces.MergeNewestWins)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if merged != 0 {
+		t.Errorf("merged = %d, want 0", merged)
+	}
+	if obj, _ := dst.Get("hash"); obj.(*types.HashObject).Fields()["f"] != "dst" {
+		t.Error("hash should keep the locally modified value")
+	}
+
+	// 合并写入的副本保留来源的修改时间，反向合并时不会被当作更新的值
+	merged, err = src.Merge(dst, interfaces.MergeNewestWins)
+	if err != nil {
+		t.Fatalf("Reverse merge failed: %v", err)
+	}
+	if merged != 2 {
+		t.Errorf("reverse merged = %d, want 2", merged)
+	}
+	if merged, _ := dst.Merge(src, interfaces.MergeNewestWins); merged != 0 {
+		t.Errorf("merging back = %d, want 0", merged)
+	}
+}
+
+func TestEngineMergeStrategies(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		strategy interfaces.MergeStrategy
+		want     string
+	}{
+		{"overwrite", interfaces.MergeOverwrite, "src"},
+		{"keep-existing", interfaces.MergeKeepExisting, "dst"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := storage.NewStorageEngine(config.DefaultEngineConfig())
+			defer dst.Close()
+			src := storage.NewStorageEngine(config.DefaultEngineConfig())
+			defer src.Close()
+
+			dst.Set("k", types.NewStringObject("dst", 0))
+			src.Set("k", types.NewStringObject("src", 0))
+
+			if _, err := dst.Merge(src, tc.strategy); err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			obj, _ := dst.Get("k")
+			if got := obj.(*types.StringObject).Value(); got != tc.want {
+				t.Errorf("k = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEngineMergeNewestWinsIgnoresDuplicateSetAdd(t *testing.T) {
+	dst := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer dst.Close()
+	src := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer src.Close()
+
+	dst.Set("set", types.NewSetObject([]interface{}{"a"}, 0))
+	time.Sleep(2 * time.Millisecond)
+	src.Set("set", types.NewSetObject([]interface{}{"b"}, 0))
+	time.Sleep(2 * time.Millisecond)
+
+	// 添加已有成员不是修改，目标中的集合仍比来源旧
+	if added, _ := dst.SAdd("set", "a"); added != 0 {
+		t.Fatalf("SAdd of an existing member added %d", added)
+	}
+	if merged, err := dst.Merge(src, interfaces.MergeNewestWins); err != nil || merged != 1 {
+		t.Errorf("Merge = %d, %v; want the newer source set merged", merged, err)
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestMSetMGet(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	err := c.MSet(map[string]interface{}{
+		"s": "v",
+		"l": []interface{}{"a", "b"},
+		"h": map[string]interface{}{"f": "x"},
+		"n": 42,
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+
+	got := c.MGet("s", "missing", "l", "h", "n")
+	want := []interface{}{"v", nil, []interface{}{"a", "b"}, map[string]interface{}{"f": "x"}, "42"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MGet = %v, want %v", got, want)
+	}
+	if ttl, ok := c.TTL("l"); !ok || ttl <= 0 {
+		t.Errorf("Expected TTL applied to all keys, got %v", ttl)
+	}
+}
+
+func TestMSetRejectsInvalidKeyWithoutWriting(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if err := c.MSet(map[string]interface{}{"ok": "v", "": "v"}); err == nil {
+		t.Fatal("Expected error for empty key")
+	}
+	if c.Exists("ok") {
+		t.Error("No key should be written when validation fails")
+	}
+}
+
+func TestMGetWithKeyNormalizer(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.KeyNormalizer = strings.ToLower
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.MSet(map[string]interface{}{"User": "alice"})
+	if got := c.MGet("USER", "user"); !reflect.DeepEqual(got, []interface{}{"alice", "alice"}) {
+		t.Errorf("MGet = %v, want values for both spellings", got)
+	}
+}
+
+func batchPairs(n int) map[string]interface{} {
+	pairs := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		pairs[fmt.Sprintf("k%02d", i)] = "v"
+	}
+	return pairs
+}
+
+func TestMSetMaxBatchSizeReject(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxBatchSize = 5
+	c := scache.New(cfg)
+	defer c.Close()
+
+	if err := c.MSet(batchPairs(5)); err != nil {
+		t.Fatalf("Batch at the limit should be accepted: %v", err)
+	}
+	c.Flush()
+
+	err := c.MSet(batchPairs(6))
+	var batchErr *scache.BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, scache.ErrBatchTooLarge) {
+		t.Fatalf("Expected BatchError wrapping ErrBatchTooLarge, got %v", err)
+	}
+	if len(batchErr.Rejected) != 6 || batchErr.Rejected[0] != "k00" {
+		t.Errorf("All keys should be reported as rejected, got %v", batchErr.Rejected)
+	}
+	if c.Size() != 0 {
+		t.Errorf("Rejected batch must not write any key, size=%d", c.Size())
+	}
+}
+
+func TestMSetMaxBatchSizeChunk(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxBatchSize = 4
+	cfg.BatchOverflow = config.BatchChunk
+	c := scache.New(cfg)
+	defer c.Close()
+
+	if err := c.MSet(batchPairs(10)); err != nil {
+		t.Fatalf("Chunked batch failed: %v", err)
+	}
+	if c.Size() != 10 {
+		t.Errorf("All keys should be written in chunks, size=%d", c.Size())
+	}
+}
+
+func TestMSetChunkReportsUnwrittenKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxBatchSize = 2
+	cfg.BatchOverflow = config.BatchChunk
+	cfg.MaxSize = 3 // 严格模式下写满后拒绝新键
+	c := scache.New(cfg)
+	defer c.Close()
+
+	err := c.MSet(batchPairs(5))
+	var batchErr *scache.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected BatchError, got %v", err)
+	}
+	if !reflect.DeepEqual(batchErr.Rejected, []string{"k03", "k04"}) {
+		t.Errorf("Rejected = %v, want keys after the failure point", batchErr.Rejected)
+	}
+	for _, key := range []string{"k00", "k01", "k02"} {
+		if !c.Exists(key) {
+			t.Errorf("%s should have been written before the failure", key)
+		}
+	}
+}
+
+func TestMaxBatchSizeValidation(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxBatchSize = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for negative max batch size")
+	}
+
+	cfg = config.DefaultEngineConfig()
+	cfg.BatchOverflow = config.BatchOverflow(9)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for unknown batch overflow mode")
+	}
+}
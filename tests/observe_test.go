@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+)
+
+// receive 在超时前读取一个值，通道关闭时ok为false
+func receive(t *testing.T, ch <-chan interface{}) (interface{}, bool) {
+	t.Helper()
+	select {
+	case value, ok := <-ch:
+		return value, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for observed value")
+		return nil, false
+	}
+}
+
+func TestObserveDeliversSetsAndClosesOnDelete(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	updates, cancel := c.Observe("k")
+	defer cancel()
+
+	c.SetString("k", "v1")
+	if value, ok := receive(t, updates); !ok || value != "v1" {
+		t.Fatalf("Expected v1, got %v, %v", value, ok)
+	}
+
+	// 其他键的变化不会投递
+	c.SetString("other", "x")
+	c.SetHash("k", map[string]interface{}{"f": "a"})
+	c.HSet("k", "g", "b")
+	value, _ := receive(t, updates)
+	if want := map[string]interface{}{"f": "a", "g": "b"}; !reflect.DeepEqual(value, want) {
+		t.Fatalf("Expected latest hash %v, got %v", want, value)
+	}
+
+	c.Delete("k")
+	if _, ok := receive(t, updates); ok {
+		t.Fatal("Channel should close when the key is deleted")
+	}
+}
+
+func TestObserveLatestValueWins(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	updates, cancel := c.Observe("counter")
+	defer cancel()
+
+	// 消费者不读取时写入不阻塞，只保留最新值
+	for i := 0; i < 100; i++ {
+		c.SetString("counter", fmt.Sprint(i))
+	}
+	if value, _ := receive(t, updates); value != "99" {
+		t.Errorf("Expected latest value 99, got %v", value)
+	}
+	select {
+	case value := <-updates:
+		t.Errorf("Expected no pending values, got %v", value)
+	default:
+	}
+}
+
+func TestObserveClosesOnExpiryAndCancel(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("short", "v", time.Millisecond)
+	expiring, cancelExpiring := c.Observe("short")
+	defer cancelExpiring()
+	time.Sleep(5 * time.Millisecond)
+
+	// 过期键在访问时移除并关闭通道
+	c.GetString("short")
+	if _, ok := receive(t, expiring); ok {
+		t.Error("Channel should close when the key expires")
+	}
+
+	updates, cancel := c.Observe("k")
+	cancel()
+	cancel()
+	if _, ok := receive(t, updates); ok {
+		t.Error("Channel should close after cancel")
+	}
+	c.SetString("k", "v")
+}
+
+func TestObserveDecodesTransformedValues(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig().WithValueTransformer(xorTransform, xorTransform))
+	defer c.Close()
+
+	updates, cancel := c.Observe("secret")
+	c.SetString("secret", "plain")
+	if value, _ := receive(t, updates); value != "plain" {
+		t.Errorf("Expected decoded value, got %v", value)
+	}
+
+	cancel()
+	if _, ok := receive(t, updates); ok {
+		t.Error("Channel should close after cancel")
+	}
+}
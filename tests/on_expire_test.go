@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/types"
+)
+
+func TestOnExpireRefreshesOrDeletes(t *testing.T) {
+	var refreshes atomic.Int32
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = 10 * time.Millisecond
+	cfg.OnExpire = func(key string, old interfaces.DataObject) (interfaces.DataObject, bool) {
+		if !strings.HasPrefix(key, "refresh:") {
+			return nil, false
+		}
+		// 第一次刷新后很快再次过期，第二次刷新后保持存活
+		ttl := 30 * time.Millisecond
+		if n := refreshes.Add(1); n > 1 {
+			ttl = time.Hour
+		}
+		return types.NewStringObject("refreshed", ttl), true
+	}
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.SetString("refresh:token", "", 20*time.Millisecond)
+	c.SetString("plain", "v", 20*time.Millisecond)
+
+	time.Sleep(150 * time.Millisecond)
+
+	if refreshes.Load() != 2 {
+		t.Errorf("Expected the key to be refreshed twice, got %d refreshes", refreshes.Load())
+	}
+	if v, ok := c.GetString("refresh:token"); !ok || v != "refreshed" {
+		t.Errorf("Refreshed key should be alive with new content, got %q, %v", v, ok)
+	}
+	if ttl, ok := c.TTL("refresh:token"); !ok || ttl <= 59*time.Minute {
+		t.Errorf("Refreshed key should carry the replacement TTL, got %v", ttl)
+	}
+	if c.Exists("plain") {
+		t.Error("Key whose OnExpire returned false should be deleted")
+	}
+}
+
+func TestOnExpireSkipsKeysRewrittenDuringCallback(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = 10 * time.Millisecond
+	var c *scache.LocalCache
+	cfg.OnExpire = func(key string, old interfaces.DataObject) (interfaces.DataObject, bool) {
+		// 回调在锁外执行，可以访问引擎；回调期间的新写入优先于回调结果
+		c.SetString(key, "written-during-callback")
+		return types.NewStringObject("from-callback", 0), true
+	}
+	c = scache.New(cfg)
+	defer c.Close()
+
+	c.SetString("k", "v", 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	if v, _ := c.GetString("k"); v != "written-during-callback" {
+		t.Errorf("Concurrent write should win over the callback result, got %q", v)
+	}
+}
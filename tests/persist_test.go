@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestPersistOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.snap")
+
+	c := scache.New(config.DefaultEngineConfig().WithPersistOnClose(path))
+	c.SetString("forever", "v")
+	c.SetString("session", "s", time.Hour)
+	c.SetString("short", "x", 20*time.Millisecond)
+	c.RPush("queue", "a", "b")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Snapshot should not exist before Close, stat err = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Close should write the snapshot: %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	restored := scache.New(config.DefaultEngineConfig().WithPersistOnClose(path))
+	defer restored.Close()
+
+	if v, _ := restored.GetString("forever"); v != "v" {
+		t.Errorf("forever = %q, want v", v)
+	}
+	if ttl, ok := restored.TTL("forever"); !ok || ttl > 0 {
+		t.Errorf("Persistent key should stay persistent, got %v", ttl)
+	}
+	if ttl, _ := restored.TTL("session"); ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("Live TTL should be preserved, got %v", ttl)
+	}
+	if restored.Exists("short") {
+		t.Error("Key that expired while the cache was down must not be restored")
+	}
+	if got := restored.LRange("queue", 0, -1); len(got) != 2 || got[0] != "a" {
+		t.Errorf("queue = %v", got)
+	}
+}
+
+func TestPersistOnCloseMissingOrCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	var restoreErrs []error
+	newConfig := func(path string) *config.EngineConfig {
+		cfg := config.DefaultEngineConfig().WithPersistOnClose(path)
+		cfg.OnRestoreError = func(p string, err error) {
+			if p != path {
+				t.Errorf("OnRestoreError path = %q, want %q", p, path)
+			}
+			restoreErrs = append(restoreErrs, err)
+		}
+		return cfg
+	}
+
+	c := scache.New(newConfig(filepath.Join(dir, "missing.snap")))
+	if c.Size() != 0 || len(restoreErrs) != 0 {
+		t.Errorf("Missing snapshot should start empty without error, size=%d errs=%v", c.Size(), restoreErrs)
+	}
+	c.Close()
+
+	corrupt := filepath.Join(dir, "corrupt.snap")
+	os.WriteFile(corrupt, []byte("not a snapshot"), 0o644)
+	c = scache.New(newConfig(corrupt))
+	if c.Size() != 0 {
+		t.Errorf("Corrupt snapshot should start empty, size=%d", c.Size())
+	}
+	if len(restoreErrs) != 1 {
+		t.Fatalf("Corrupt snapshot should be reported once, got %v", restoreErrs)
+	}
+
+	// 恢复失败的文件在关闭时不被覆盖
+	c.SetString("k", "v")
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if data, _ := os.ReadFile(corrupt); string(data) != "not a snapshot" {
+		t.Errorf("Close must not overwrite a snapshot that failed to restore, got %q", data)
+	}
+}
+
+func TestPersistOnCloseUnwritablePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "no-such-dir", "cache.snap")
+	c := scache.New(config.DefaultEngineConfig().WithPersistOnClose(path))
+	c.SetString("k", "v")
+
+	if err := c.Close(); err == nil {
+		t.Error("Expected error when the snapshot cannot be written")
+	}
+	if !c.GetEngine().Closed() {
+		t.Error("Cache should be closed even if persisting fails")
+	}
+}
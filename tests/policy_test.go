@@ -0,0 +1,231 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/policies/lru"
+	"github.com/scache-io/scache/policies/ttllru"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestTTLLRUPolicyEvictsSoonestExpiringFirst(t *testing.T) {
+	policy := ttllru.NewTTLLRUPolicy(10).(interfaces.ExpiryAwarePolicy)
+	now := time.Now()
+
+	policy.Set("permanent_old")
+	policy.SetExpiry("permanent_old", time.Time{})
+	policy.Set("ttl_late")
+	policy.SetExpiry("ttl_late", now.Add(time.Hour))
+	policy.Set("ttl_soon")
+	policy.SetExpiry("ttl_soon", now.Add(time.Minute))
+	policy.Set("permanent_new")
+
+	expected := []string{"ttl_soon", "ttl_late", "permanent_old", "permanent_new"}
+	for _, want := range expected {
+		if got := policy.Evict(); got != want {
+			t.Fatalf("Expected eviction of %s, got %s", want, got)
+		}
+	}
+	if got := policy.Evict(); got != "" {
+		t.Errorf("Expected empty policy, got %s", got)
+	}
+}
+
+func TestTTLLRUPolicyWithEngine(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 3
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.PolicyFactory = ttllru.NewTTLLRUPolicy
+	cache := scache.New(cfg)
+
+	cache.SetString("permanent", "v")
+	cache.SetString("ttl_late", "v", time.Hour)
+	cache.SetString("ttl_soon", "v", time.Minute)
+
+	// 访问TTL键使永久键成为最久未使用，组合策略仍应先淘汰最快过期的键
+	cache.GetString("ttl_soon")
+	cache.SetString("new", "v")
+
+	if cache.Exists("ttl_soon") {
+		t.Error("Soonest-expiring key should be evicted first")
+	}
+	for _, key := range []string{"permanent", "ttl_late", "new"} {
+		if !cache.Exists(key) {
+			t.Errorf("Key %s should remain", key)
+		}
+	}
+
+	// 清除TTL后键不再优先淘汰
+	cache.Expire("ttl_late", 0)
+	cache.SetString("another", "v")
+	if !cache.Exists("ttl_late") {
+		t.Error("Key made permanent should fall back to LRU order")
+	}
+	if cache.Exists("permanent") {
+		t.Error("Least recently used permanent key should be evicted")
+	}
+}
+
+func TestPolicyStats(t *testing.T) {
+	policy := lru.NewLRUPolicy(10)
+
+	policy.Set("a")
+	policy.Set("b")
+	policy.Access("a")
+	policy.Evict() // 淘汰b
+	policy.Delete("a")
+	policy.Evict() // 空策略，不计入淘汰
+
+	stats := policy.Stats()
+	if stats.Operations != 6 {
+		t.Errorf("Expected 6 operations, got %d", stats.Operations)
+	}
+	if stats.Evictions != 1 {
+		t.Errorf("Expected 1 eviction, got %d", stats.Evictions)
+	}
+	if time.Since(stats.LastOpTime) > time.Second {
+		t.Errorf("LastOpTime should be recent, got %v", stats.LastOpTime)
+	}
+
+	composite := ttllru.NewTTLLRUPolicy(10).(interfaces.ExpiryAwarePolicy)
+	composite.Set("ttl")
+	composite.SetExpiry("ttl", time.Now().Add(time.Minute))
+	composite.Set("permanent")
+	composite.Evict()
+	composite.Evict()
+	if stats := composite.Stats(); stats.Operations != 4 || stats.Evictions != 2 {
+		t.Errorf("Expected 4 operations and 2 evictions, got %+v", stats)
+	}
+}
+
+func TestEngineStatsIncludePolicy(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cfg.BackgroundCleanupInterval = time.Minute
+	cache := scache.New(cfg)
+
+	cache.SetString("a", "1")
+	cache.SetString("b", "2")
+	cache.SetString("c", "3") // 触发一次淘汰
+
+	stats := cache.Stats().(map[string]interface{})
+	policyStats, ok := stats["policy"].(interfaces.PolicyStats)
+	if !ok {
+		t.Fatalf("Expected policy stats in engine stats, got %T", stats["policy"])
+	}
+	if policyStats.Evictions != 1 {
+		t.Errorf("Expected 1 policy eviction, got %d", policyStats.Evictions)
+	}
+	if policyStats.Operations != 4 {
+		t.Errorf("Expected 4 policy operations (3 sets + 1 evict), got %d", policyStats.Operations)
+	}
+}
+
+func TestStrictAccessSkipsUnknownKeys(t *testing.T) {
+	for name, newPolicy := range map[string]config.PolicyFactory{
+		"lru":    lru.NewLRUPolicy,
+		"ttllru": ttllru.NewTTLLRUPolicy,
+	} {
+		t.Run(name, func(t *testing.T) {
+			policy := newPolicy(10)
+
+			// 默认模式保持原有行为：Access添加未知键
+			policy.Access("legacy")
+			if !policy.Contains("legacy") {
+				t.Fatal("non-strict Access should insert unknown keys")
+			}
+
+			policy.(interfaces.StrictAccessPolicy).SetStrictAccess(true)
+			policy.Access("phantom")
+			if policy.Contains("phantom") {
+				t.Error("strict Access must not create a phantom entry")
+			}
+			if policy.Size() != 1 {
+				t.Errorf("Expected size 1, got %d", policy.Size())
+			}
+
+			policy.Set("stored")
+			policy.Access("stored")
+			if !policy.Contains("stored") {
+				t.Error("Set should still insert keys in strict mode")
+			}
+
+			if expiry, ok := policy.(interfaces.ExpiryAwarePolicy); ok {
+				expiry.SetExpiry("phantom", time.Now().Add(time.Minute))
+				if got := policy.Evict(); got == "phantom" {
+					t.Error("strict SetExpiry must not track unknown keys")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicySizeNeverExceedsStorage(t *testing.T) {
+	var policy interfaces.EvictionPolicy
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 1000
+	cfg.StrictPolicyAccess = true
+	cfg.PolicyFactory = func(capacity int) interfaces.EvictionPolicy {
+		policy = ttllru.NewTTLLRUPolicy(capacity)
+		return policy
+	}
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	// 读取与删除并发执行，Get在释放读锁后才调用Access，非严格模式下会把已删除的键重新加入策略
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				key := fmt.Sprintf("key-%d", i%50)
+				switch (i + w) % 3 {
+				case 0:
+					engine.Set(key, types.NewStringObject("v", 0))
+				case 1:
+					engine.Get(key)
+				default:
+					engine.Delete(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	engine.Get("never-stored")
+	if policy.Size() > engine.Size() {
+		t.Errorf("Policy holds %d keys but storage only %d", policy.Size(), engine.Size())
+	}
+	for _, key := range policy.Keys() {
+		if !engine.Exists(key) {
+			t.Errorf("Policy holds phantom key %q", key)
+		}
+	}
+}
+
+func TestStrictPolicyAccessIsOptIn(t *testing.T) {
+	if config.DefaultEngineConfig().StrictPolicyAccess {
+		t.Error("DefaultEngineConfig must leave StrictPolicyAccess off")
+	}
+
+	policy := lru.NewLRUPolicy(10)
+	engine := storage.NewStorageEngine(&config.EngineConfig{
+		MaxSize:       10,
+		PolicyFactory: func(int) interfaces.EvictionPolicy { return policy },
+	})
+	defer engine.Close()
+
+	// 默认非严格模式下，Access仍为未知键创建条目
+	policy.Access("unknown")
+	if !policy.Contains("unknown") {
+		t.Error("Policy should stay non-strict unless StrictPolicyAccess is set")
+	}
+}
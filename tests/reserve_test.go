@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestReserveSurvivesEvictionPressure(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 3
+	cfg.BackgroundCleanupInterval = time.Minute
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	commit, cancel := c.Reserve("big")
+	defer cancel()
+
+	if _, ok := c.GetString("big"); ok {
+		t.Fatal("reserved key should read as missing")
+	}
+	if c.Exists("big") {
+		t.Fatal("reserved key should not exist before commit")
+	}
+
+	// 预留占用一个槽位，其余写入在剩余两个槽位间淘汰
+	for i := 0; i < 10; i++ {
+		c.SetString(fmt.Sprintf("k%d", i), "v")
+	}
+	if got := c.Size(); got != 2 {
+		t.Fatalf("Size() = %d, want 2 with one reserved slot", got)
+	}
+
+	if err := commit("built", time.Minute); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if v, ok := c.GetString("big"); !ok || v != "built" {
+		t.Fatalf("GetString(big) = %q, %v after commit", v, ok)
+	}
+	if got := c.Size(); got != 3 {
+		t.Errorf("Size() = %d after commit, want 3", got)
+	}
+
+	stats := c.Stats().(map[string]interface{})
+	if stats["reserved"] != 0 {
+		t.Errorf("reserved = %v after commit, want 0", stats["reserved"])
+	}
+}
+
+func TestReserveCancelRemovesPlaceholder(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	c.SetString("old", "v")
+	_, cancel := c.Reserve("old")
+	if c.Exists("old") {
+		t.Fatal("reserving an existing key should hide its previous value")
+	}
+
+	// 严格模式下预留占满容量时拒绝新写入
+	c.SetString("a", "1")
+	if err := c.SetString("b", "2"); err == nil {
+		t.Fatal("expected capacity error while slot is reserved")
+	}
+
+	cancel()
+	if err := c.SetString("b", "2"); err != nil {
+		t.Fatalf("SetString after cancel: %v", err)
+	}
+	if stats := c.Stats().(map[string]interface{}); stats["reserved"] != 0 {
+		t.Errorf("reserved = %v after cancel, want 0", stats["reserved"])
+	}
+}
+
+func TestReserveCommitStoresStruct(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig())
+	defer c.Close()
+
+	type report struct {
+		Rows int `json:"rows"`
+	}
+
+	commit, _ := c.Reserve("report")
+	if err := commit(report{Rows: 42}, 0); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	var got report
+	if err := c.Load("report", &got); err != nil || got.Rows != 42 {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+}
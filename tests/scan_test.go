@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+// scanAll 从游标0开始遍历到结束，返回所有键和调用次数
+func scanAll(t *testing.T, c *scache.LocalCache, match string, count int, between func(round int)) ([]string, int) {
+	t.Helper()
+	var keys []string
+	cursor, rounds := 0, 0
+	for {
+		next, batch := c.Scan(cursor, match, count)
+		keys = append(keys, batch...)
+		rounds++
+		if next == 0 {
+			return keys, rounds
+		}
+		if next <= cursor {
+			t.Fatalf("Cursor must move forward: %d -> %d", cursor, next)
+		}
+		if rounds > 10000 {
+			t.Fatal("Scan did not terminate")
+		}
+		cursor = next
+		if between != nil {
+			between(rounds)
+		}
+	}
+}
+
+func TestScanVisitsEveryKeyOnce(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	var want []string
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key:%03d", i)
+		c.SetString(key, "v")
+		want = append(want, key)
+	}
+
+	got, rounds := scanAll(t, c, "", 7, nil)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan should return every key once in write order, got %d keys", len(got))
+	}
+	if rounds != 15 {
+		t.Errorf("Expected 15 calls with count 7, got %d", rounds)
+	}
+
+	// 覆盖写入不改变键的位置
+	c.SetString("key:000", "updated")
+	if got, _ := scanAll(t, c, "", 50, nil); !reflect.DeepEqual(got, want) {
+		t.Error("Overwriting a key must not change scan order")
+	}
+}
+
+func TestScanMatch(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	for i := 0; i < 20; i++ {
+		c.SetString(fmt.Sprintf("user:%d", i), "v")
+		c.SetString(fmt.Sprintf("order:%d", i), "v")
+	}
+
+	got, _ := scanAll(t, c, "user:1*", 4, nil)
+	sort.Strings(got)
+	want := []string{"user:1", "user:10", "user:11", "user:12", "user:13", "user:14", "user:15", "user:16", "user:17", "user:18", "user:19"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan MATCH = %v", got)
+	}
+
+	if next, keys := c.Scan(0, "user:[", 10); next != 0 || keys != nil {
+		t.Errorf("Malformed pattern should return 0, nil; got %d, %v", next, keys)
+	}
+}
+
+func TestScanToleratesMutationsBetweenCalls(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	for i := 0; i < 200; i++ {
+		c.SetString(fmt.Sprintf("stable:%03d", i), "v")
+		c.SetString(fmt.Sprintf("churn:%03d", i), "v")
+	}
+
+	added := 0
+	got, _ := scanAll(t, c, "", 10, func(round int) {
+		// 每轮删除一批旧键并写入新键
+		for j := 0; j < 5; j++ {
+			c.Delete(fmt.Sprintf("churn:%03d", (round*5+j)%200))
+			c.SetString(fmt.Sprintf("new:%05d", added), "v")
+			added++
+		}
+	})
+
+	seen := make(map[string]int)
+	for _, key := range got {
+		seen[key]++
+	}
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("stable:%03d", i)
+		if seen[key] != 1 {
+			t.Errorf("%s returned %d times, want exactly once", key, seen[key])
+		}
+	}
+	for key, n := range seen {
+		if n > 1 {
+			t.Errorf("%s returned %d times", key, n)
+		}
+		if !c.Exists(key) && key[:6] == "stable" {
+			t.Errorf("Scan returned missing key %s", key)
+		}
+	}
+}
+
+func TestScanAfterHeavyChurn(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("keep", "v")
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("tmp:%d", i)
+		c.SetString(key, "v")
+		c.Delete(key)
+	}
+	// 删除后重新写入的键移到末尾，只返回一次
+	c.Delete("keep")
+	c.SetString("keep", "v")
+	c.SetString("last", "v")
+
+	if got, _ := scanAll(t, c, "", 3, nil); !reflect.DeepEqual(got, []string{"keep", "last"}) {
+		t.Errorf("Scan after churn = %v", got)
+	}
+}
+
+func TestScanAfterFlush(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("a", "v")
+	cursor, _ := c.Scan(0, "", 1)
+	c.Flush()
+	c.SetString("b", "v")
+
+	if next, keys := c.Scan(cursor, "", 10); next != 0 || !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Errorf("Cursor from before Flush should still see new keys, got %d, %v", next, keys)
+	}
+	if got, _ := scanAll(t, c, "", 10, nil); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Scan after Flush = %v", got)
+	}
+}
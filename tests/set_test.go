@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/types"
+)
+
+func TestSetCommands(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if n, err := c.SAdd("tags", "go", "cache", "go"); n != 2 || err != nil {
+		t.Fatalf("SAdd on new key = %d, %v, want 2", n, err)
+	}
+	if n, _ := c.SAdd("tags", "cache", "redis"); n != 1 {
+		t.Errorf("SAdd should count only new members, got %d", n)
+	}
+	if dt, _ := c.GetEngine().Type("tags"); string(dt) != "set" {
+		t.Errorf("Type = %q, want set", dt)
+	}
+
+	members, ok := c.SMembers("tags")
+	if !ok || !reflect.DeepEqual(members, []interface{}{"cache", "go", "redis"}) {
+		t.Errorf("SMembers = %v, %v", members, ok)
+	}
+	if !c.SIsMember("tags", "go") || c.SIsMember("tags", "java") {
+		t.Error("SIsMember reported wrong membership")
+	}
+	if c.SCard("tags") != 3 || c.SCard("missing") != 0 {
+		t.Errorf("SCard = %d", c.SCard("tags"))
+	}
+
+	if n, _ := c.SRem("tags", "go", "java"); n != 1 {
+		t.Errorf("SRem should count only removed members, got %d", n)
+	}
+	if n, _ := c.SRem("tags", "cache", "redis"); n != 2 {
+		t.Errorf("SRem = %d, want 2", n)
+	}
+	if c.Exists("tags") {
+		t.Error("Removing the last member should delete the key")
+	}
+	if n, err := c.SRem("tags", "go"); n != 0 || err != nil {
+		t.Errorf("SRem on missing key = %d, %v", n, err)
+	}
+}
+
+func TestSetTypeErrors(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	c.SetString("str", "v")
+	if _, err := c.SAdd("str", "a"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("SAdd on string key: got %v", err)
+	}
+	if _, err := c.SRem("str", "a"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("SRem on string key: got %v", err)
+	}
+	if _, ok := c.SMembers("str"); ok {
+		t.Error("SMembers on string key should miss")
+	}
+
+	if _, err := c.SAdd("set"); !errors.Is(err, scache.ErrInvalidArgument) {
+		t.Errorf("SAdd without members: got %v", err)
+	}
+	if _, err := c.SAdd("set", "a", []string{"b"}); !errors.Is(err, scache.ErrInvalidArgument) {
+		t.Errorf("SAdd with slice member: got %v", err)
+	}
+	if c.Exists("set") {
+		t.Error("Rejected SAdd must not create the key")
+	}
+	if c.SIsMember("set", map[string]int{}) {
+		t.Error("Uncomparable member cannot be in a set")
+	}
+}
+
+func TestSetConcurrentAdd(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				n, _ := c.SAdd("ids", j)
+				mu.Lock()
+				total += n
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if total != 100 || c.SCard("ids") != 100 {
+		t.Errorf("Each member should be added exactly once: total=%d card=%d", total, c.SCard("ids"))
+	}
+}
+
+func TestSetSnapshotRoundTrip(t *testing.T) {
+	src := scache.New(config.DefaultEngineConfig())
+	defer src.Close()
+	src.SAdd("tags", "a", "b")
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dst := scache.New(config.DefaultEngineConfig())
+	defer dst.Close()
+	if err := dst.Import(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if members, _ := dst.SMembers("tags"); !reflect.DeepEqual(members, []interface{}{"a", "b"}) {
+		t.Errorf("Imported members = %v", members)
+	}
+	if obj, _ := dst.GetEngine().Get("tags"); obj.Size() != types.NewSetObject([]interface{}{"a", "b"}, 0).Size() {
+		t.Errorf("Imported set size mismatch: %d", obj.Size())
+	}
+}
+
+func TestSetMemoryAccounting(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	c := scache.New(cfg)
+	defer c.Close()
+
+	for i := 0; i < 1000; i++ {
+		c.SAdd("s", i, fmt.Sprintf("m%d", i))
+	}
+	for i := 0; i < 1000; i += 3 {
+		c.SRem("s", i, "missing")
+	}
+	members, _ := c.SMembers("s")
+	data, _ := json.Marshal(members)
+	if got := c.Stats().(map[string]interface{})["memory"]; got != int64(len(data)) {
+		t.Errorf("Memory usage = %v, want %d", got, len(data))
+	}
+
+	c.SRem("s", members...)
+	if got := c.Stats().(map[string]interface{})["memory"]; got != int64(0) {
+		t.Errorf("Memory usage after removing every member = %v, want 0", got)
+	}
+}
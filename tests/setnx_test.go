@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+)
+
+func TestSetNX(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	if ok, err := c.SetNX("lock", "owner-1", time.Minute); !ok || err != nil {
+		t.Fatalf("SetNX on absent key = %v, %v", ok, err)
+	}
+	if ok, err := c.SetNX("lock", "owner-2"); ok || err != nil {
+		t.Fatalf("SetNX on existing key = %v, %v", ok, err)
+	}
+	if got, _ := c.GetString("lock"); got != "owner-1" {
+		t.Errorf("Existing value must be untouched, got %q", got)
+	}
+
+	// 过期的键视为不存在
+	c.SetString("stale", "old", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if ok, _ := c.SetNX("stale", "new"); !ok {
+		t.Error("SetNX should succeed on an expired key")
+	}
+}
+
+func TestSetNXConcurrentSingleWinner(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	var winners atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := c.SetNX("lock", "v"); ok {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := winners.Load(); n != 1 {
+		t.Errorf("Expected exactly one SetNX winner, got %d", n)
+	}
+}
+
+func TestSetWithOptions(t *testing.T) {
+	c := scache.New(config.DefaultEngineConfig())
+	defer c.Close()
+
+	// XX只在键存在时写入
+	if ok, _ := c.SetWithOptions("k", "v", 0, interfaces.SetOptions{XX: true}); ok || c.Exists("k") {
+		t.Error("XX must not create a missing key")
+	}
+
+	c.SetString("k", "v1", time.Hour)
+	if ok, _ := c.SetWithOptions("k", "v2", 0, interfaces.SetOptions{XX: true, KeepTTL: true}); !ok {
+		t.Fatal("XX should overwrite an existing key")
+	}
+	if got, _ := c.GetString("k"); got != "v2" {
+		t.Errorf("Expected v2, got %q", got)
+	}
+	if ttl, ok := c.TTL("k"); !ok || ttl <= 59*time.Minute {
+		t.Errorf("KeepTTL should retain the previous expiry, got %v", ttl)
+	}
+
+	// 不使用KeepTTL时按新的TTL写入
+	c.SetWithOptions("k", "v3", 0, interfaces.SetOptions{})
+	if ttl, _ := c.TTL("k"); ttl >= 0 {
+		t.Errorf("Expected the key to become permanent, got TTL %v", ttl)
+	}
+
+	if _, err := c.SetWithOptions("k", "v", 0, interfaces.SetOptions{NX: true, XX: true}); !errors.Is(err, scache.ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument for NX+XX, got %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func newShardedConfig(shards int) *config.EngineConfig {
+	cfg := config.DefaultEngineConfig()
+	cfg.Shards = shards
+	return cfg
+}
+
+func TestShardsConfigValidation(t *testing.T) {
+	for _, shards := range []int{-1, 3, 12, 8192} {
+		if err := newShardedConfig(shards).Validate(); err == nil {
+			t.Errorf("Shards = %d should be rejected", shards)
+		}
+	}
+	for _, shards := range []int{0, 1, 2, 16, 4096} {
+		if err := newShardedConfig(shards).Validate(); err != nil {
+			t.Errorf("Shards = %d should be valid: %v", shards, err)
+		}
+	}
+
+	cfg := newShardedConfig(16)
+	cfg.MaxSize = 8
+	if err := cfg.Validate(); err == nil {
+		t.Error("MaxSize smaller than Shards should be rejected")
+	}
+}
+
+func TestDefaultConfigKeepsSingleLockEngine(t *testing.T) {
+	if shards := config.DefaultEngineConfig().Shards; shards != 1 {
+		t.Errorf("Default Shards = %d, want 1", shards)
+	}
+	engine := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer engine.Close()
+	if _, ok := engine.(*storage.StorageEngine); !ok {
+		t.Errorf("Default engine = %T, want *storage.StorageEngine", engine)
+	}
+
+	sharded := storage.NewStorageEngine(newShardedConfig(8))
+	defer sharded.Close()
+	if _, ok := sharded.(*storage.ShardedEngine); !ok {
+		t.Errorf("Engine with 8 shards = %T, want *storage.ShardedEngine", sharded)
+	}
+}
+
+func TestShardedEngineAggregatesAcrossShards(t *testing.T) {
+	engine := storage.NewStorageEngine(newShardedConfig(8))
+	defer engine.Close()
+
+	var want []string
+	for i := 0; i < 500; i++ {
+		key := fmt.Sprintf("key:%03d", i)
+		engine.Set(key, types.NewStringObject("v", 0))
+		want = append(want, key)
+	}
+
+	if size := engine.Size(); size != 500 {
+		t.Errorf("Size = %d, want 500", size)
+	}
+	keys := engine.Keys()
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys returned %d keys, want 500", len(keys))
+	}
+	if got := engine.KeysMatch("key:00*"); !reflect.DeepEqual(got, want[:10]) {
+		t.Errorf("KeysMatch = %v, want %v", got, want[:10])
+	}
+
+	// Scan跨分片遍历每个键恰好一次，游标单调递增
+	var scanned []string
+	cursor := 0
+	for rounds := 0; ; rounds++ {
+		next, batch := engine.Scan(cursor, "", 7)
+		scanned = append(scanned, batch...)
+		if next == 0 {
+			break
+		}
+		if next <= cursor || rounds > 1000 {
+			t.Fatalf("Scan cursor must move forward: %d -> %d", cursor, next)
+		}
+		cursor = next
+	}
+	sort.Strings(scanned)
+	if !reflect.DeepEqual(scanned, want) {
+		t.Errorf("Scan returned %d keys, want each of 500 keys once", len(scanned))
+	}
+
+	for _, key := range want[:100] {
+		engine.Get(key)
+	}
+	engine.Get("missing")
+	stats := engine.Stats().(map[string]interface{})
+	if stats["keys"] != 500 || stats["hits"] != int64(100) || stats["misses"] != int64(1) || stats["shards"] != 8 {
+		t.Errorf("Stats = keys %v, hits %v, misses %v, shards %v", stats["keys"], stats["hits"], stats["misses"], stats["shards"])
+	}
+	if stats["memory"] != int64(500*types.NewStringObject("v", 0).Size()) {
+		t.Errorf("Stats memory = %v", stats["memory"])
+	}
+
+	if err := engine.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if size := engine.Size(); size != 0 {
+		t.Errorf("Size after Flush = %d, want 0", size)
+	}
+}
+
+func TestShardedEngineSplitsMaxSize(t *testing.T) {
+	cfg := newShardedConfig(4)
+	cfg.MaxSize = 64
+	cfg.BackgroundCleanupInterval = time.Minute
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	for i := 0; i < 1000; i++ {
+		engine.Set(fmt.Sprintf("key:%d", i), types.NewStringObject("v", 0))
+	}
+	if size := engine.Size(); size > 64 || size < 48 {
+		t.Errorf("Size = %d, want each of 4 shards capped at 16", size)
+	}
+}
+
+func TestShardedEngineCrossShardMoves(t *testing.T) {
+	engine := storage.NewStorageEngine(newShardedConfig(16))
+	defer engine.Close()
+
+	for i := 0; i < 50; i++ {
+		src, dst := fmt.Sprintf("src:%d", i), fmt.Sprintf("dst:%d", i)
+		engine.Set(src, types.NewStringObject("v", 0))
+		if !engine.RenameIf(src, dst, "v") {
+			t.Fatalf("RenameIf(%s, %s) failed", src, dst)
+		}
+		if engine.Exists(src) || !engine.Exists(dst) {
+			t.Fatalf("RenameIf(%s, %s) should move the key", src, dst)
+		}
+
+		from, to := fmt.Sprintf("from:%d", i), fmt.Sprintf("to:%d", i)
+		engine.RPush(from, "a", "b")
+		if value, err := engine.LMove(from, to, interfaces.ListLeft, interfaces.ListRight); err != nil || value != "a" {
+			t.Fatalf("LMove(%s, %s) = %v, %v", from, to, value, err)
+		}
+		if value, err := engine.LMove(from, to, interfaces.ListLeft, interfaces.ListRight); err != nil || value != "b" {
+			t.Fatalf("LMove(%s, %s) = %v, %v", from, to, value, err)
+		}
+		if engine.Exists(from) {
+			t.Fatalf("LMove should delete the emptied source %s", from)
+		}
+	}
+
+	// 跨分片移动后内存统计与存活对象一致
+	var want int64
+	for _, key := range engine.Keys() {
+		obj, _ := engine.Get(key)
+		want += int64(obj.Size())
+	}
+	if got := engine.Stats().(map[string]interface{})["memory"]; got != want {
+		t.Errorf("Stats memory = %v, want %d", got, want)
+	}
+}
+
+func TestShardedEngineRenameIfMovesDirectly(t *testing.T) {
+	cfg := newShardedConfig(16)
+	cfg.AdmissionFilter = func(key string) bool {
+		return !strings.HasPrefix(key, "moved:")
+	}
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	for i := 0; i < 20; i++ {
+		engine.Set(fmt.Sprintf("key:%d", i), types.NewStringObject("v", 0))
+	}
+	sets := engine.Stats().(map[string]interface{})["sets"]
+
+	// 与单引擎相同，重命名不是新写入：不经过准入过滤，也不计入写入统计
+	for i := 0; i < 20; i++ {
+		src, dst := fmt.Sprintf("key:%d", i), fmt.Sprintf("moved:%d", i)
+		if !engine.RenameIf(src, dst, "v") || engine.Exists(src) || !engine.Exists(dst) {
+			t.Fatalf("RenameIf(%s, %s) should move the key", src, dst)
+		}
+	}
+	if got := engine.Stats().(map[string]interface{})["sets"]; got != sets {
+		t.Errorf("Stats sets = %v after renames, want %v", got, sets)
+	}
+}
+
+func TestShardedEngineConcurrentLMoveKeepsAllElements(t *testing.T) {
+	engine := storage.NewStorageEngine(newShardedConfig(16))
+	defer engine.Close()
+
+	const lists, perList = 8, 50
+	for i := 0; i < lists; i++ {
+		for j := 0; j < perList; j++ {
+			engine.RPush(fmt.Sprintf("list:%d", i), j)
+		}
+	}
+
+	// 反向移动同时锁定同一对分片，按序号加锁时不会死锁
+	var wg sync.WaitGroup
+	for g := 0; g < 2*lists; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			src, dst := fmt.Sprintf("list:%d", g%lists), fmt.Sprintf("list:%d", (g+1)%lists)
+			if g >= lists {
+				src, dst = dst, src
+			}
+			for n := 0; n < 200; n++ {
+				engine.LMove(src, dst, interfaces.ListLeft, interfaces.ListRight)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	total := 0
+	for i := 0; i < lists; i++ {
+		if obj, ok := engine.Get(fmt.Sprintf("list:%d", i)); ok {
+			total += obj.(interfaces.ListObject).Len()
+		}
+	}
+	if total != lists*perList {
+		t.Errorf("Total elements = %d, want %d", total, lists*perList)
+	}
+}
+
+func TestShardedEngineNormalizesBeforeRouting(t *testing.T) {
+	cfg := newShardedConfig(8)
+	cfg.KeyNormalizer = strings.ToLower
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	for i := 0; i < 20; i++ {
+		engine.Set(fmt.Sprintf("Key:%d", i), types.NewStringObject("v", 0))
+		if !engine.Exists(fmt.Sprintf("KEY:%d", i)) {
+			t.Fatalf("KEY:%d should resolve to the same shard as Key:%d", i, i)
+		}
+	}
+
+	found := engine.MGetTouch([]string{"KEY:1", "key:1", "missing"})
+	if len(found) != 2 {
+		t.Errorf("MGetTouch found %d keys, want 2", len(found))
+	}
+	removed, results := engine.DeleteMany([]string{"KEY:1", "key:1", "key:2"})
+	if removed != 2 || !results["KEY:1"] || results["key:1"] || !results["key:2"] {
+		t.Errorf("DeleteMany = %d, %v", removed, results)
+	}
+	if ttls := engine.MTTL("KEY:3", "missing", "key:4"); !reflect.DeepEqual(ttls, []int{-1, -2, -1}) {
+		t.Errorf("MTTL = %v, want [-1 -2 -1]", ttls)
+	}
+}
+
+func TestShardedEngineSnapshotRoundTrip(t *testing.T) {
+	sharded := storage.NewStorageEngine(newShardedConfig(8))
+	defer sharded.Close()
+	for i := 0; i < 100; i++ {
+		sharded.Set(fmt.Sprintf("key:%d", i), types.NewStringObject(fmt.Sprint(i), time.Hour))
+	}
+	sharded.HSet("hash", "f", "v")
+
+	var buf bytes.Buffer
+	if err := sharded.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	single := storage.NewStorageEngine(config.DefaultEngineConfig())
+	defer single.Close()
+	if err := single.Import(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if added, changed, removed := sharded.Diff(single); len(added)+len(changed)+len(removed) != 0 {
+		t.Errorf("Diff after import = %v, %v, %v", added, changed, removed)
+	}
+
+	resharded := storage.NewStorageEngine(newShardedConfig(4))
+	defer resharded.Close()
+	if merged, err := resharded.Merge(single, interfaces.MergeOverwrite); err != nil || merged != 101 {
+		t.Fatalf("Merge = %d, %v; want 101", merged, err)
+	}
+	if size := resharded.Size(); size != 101 {
+		t.Errorf("Size after merge = %d, want 101", size)
+	}
+}
+
+func TestShardedLocalCache(t *testing.T) {
+	cfg := newShardedConfig(16)
+	cfg.AccessLogSize = 256
+	c := scache.New(cfg)
+	defer c.Close()
+
+	for i := 0; i < 10; i++ {
+		c.SetString(fmt.Sprintf("cold:%d", i), "v")
+		c.GetString(fmt.Sprintf("cold:%d", i))
+	}
+	c.SetString("hot", "v")
+	for i := 0; i < 20; i++ {
+		c.GetString("hot")
+	}
+
+	if hot := c.HotKeys(time.Minute, 2); len(hot) != 2 || hot[0].Key != "hot" || hot[0].Accesses != 20 {
+		t.Errorf("HotKeys = %+v, want hot with 20 accesses first", hot)
+	}
+	if size := c.Size(); size != 11 {
+		t.Errorf("Size = %d, want 11", size)
+	}
+}
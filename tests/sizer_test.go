@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/types"
+)
+
+// sizeWithin 校验上报大小与实际序列化长度的差距在允许范围内
+func sizeWithin(t *testing.T, name string, got, want int) {
+	t.Helper()
+	margin := want / 20
+	if margin < 2 {
+		margin = 2
+	}
+	if got < want-margin || got > want+margin {
+		t.Errorf("%s: Size() = %d, serialized length = %d", name, got, want)
+	}
+}
+
+func TestDataObjectSizeMatchesSerializedLength(t *testing.T) {
+	str := types.NewStringObject("hello, 世界", 0)
+	if str.Size() != len("hello, 世界") {
+		t.Errorf("string: Size() = %d, want %d", str.Size(), len("hello, 世界"))
+	}
+
+	values := []interface{}{"a", 1, 2.5, true, nil, "quote\"d", []interface{}{"x", 2}, map[string]interface{}{"k": "v"}}
+	list := types.NewListObject(values, 0)
+	data, _ := json.Marshal(values)
+	sizeWithin(t, "list", list.Size(), len(data))
+
+	fields := map[string]interface{}{
+		"name":   "alice",
+		"age":    30,
+		"score":  99.5,
+		"tags":   []interface{}{"a", "b"},
+		"nested": map[string]interface{}{"x": 1, "y": []interface{}{1, 2, 3}},
+		"html":   "<b>&</b>",
+	}
+	hash := types.NewHashObject(fields, 0)
+	data, _ = json.Marshal(fields)
+	sizeWithin(t, "hash", hash.Size(), len(data))
+
+	type point struct {
+		X, Y int
+	}
+	data, _ = json.Marshal(point{1, 2})
+	sizeWithin(t, "fallback", types.ValueSize(point{1, 2}), len(data))
+}
+
+func TestEngineMemoryTracksSize(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	memory := func() int64 {
+		return c.Stats().(map[string]interface{})["memory"].(int64)
+	}
+
+	c.SetString("s", "hello")
+	c.SetList("l", []interface{}{"a", "b"})
+	c.SetHash("h", map[string]interface{}{"f": 1})
+	want := int64(len("hello") + len(`["a","b"]`) + len(`{"f":1}`))
+	if got := memory(); got != want {
+		t.Fatalf("memory = %d, want %d", got, want)
+	}
+
+	// 覆盖写入只计算新值
+	c.SetString("s", "hi")
+	want -= int64(len("hello") - len("hi"))
+	if got := memory(); got != want {
+		t.Fatalf("memory after overwrite = %d, want %d", got, want)
+	}
+
+	c.HSet("h", "g", "v")
+	want += int64(len(`,"g":"v"`))
+	if got := memory(); got != want {
+		t.Fatalf("memory after HSet = %d, want %d", got, want)
+	}
+
+	c.Delete("s")
+	c.Delete("l")
+	c.Delete("h")
+	if got := memory(); got != 0 {
+		t.Fatalf("memory after deletes = %d, want 0", got)
+	}
+}
+
+func TestDataObjectSizeStaysExactUnderMutation(t *testing.T) {
+	list := types.NewListObject([]interface{}{"a", 1}, 0)
+	list.Push("tail")
+	list.PushFront(map[string]interface{}{"k": "v"})
+	list.Pop()
+	list.PopFront()
+	list.PopFront()
+	data, _ := json.Marshal(list.Values())
+	if list.Size() != len(data) {
+		t.Errorf("list: Size() = %d, serialized length = %d", list.Size(), len(data))
+	}
+
+	ring := types.NewCircularListObject(3, []interface{}{"a", "bb", "ccc", "dddd"}, 0)
+	ring.PushFront("e")
+	ring.Push(12345)
+	ring.Pop()
+	data, _ = json.Marshal(ring.Values())
+	if ring.Size() != len(data) {
+		t.Errorf("ring: Size() = %d, serialized length = %d", ring.Size(), len(data))
+	}
+
+	hash := types.NewHashObject(map[string]interface{}{"a": 1}, 0)
+	hash.Set("b", "two")
+	hash.Set("a", "replaced")
+	hash.Delete("b")
+	data, _ = json.Marshal(hash.Fields())
+	if hash.Size() != len(data) {
+		t.Errorf("hash: Size() = %d, serialized length = %d", hash.Size(), len(data))
+	}
+	hash.Delete("a")
+	if hash.Size() != 2 {
+		t.Errorf("empty hash: Size() = %d, want 2", hash.Size())
+	}
+
+	set := types.NewSetObject([]interface{}{"x", 1}, 0)
+	set.Add("yy")
+	set.Add("yy")
+	set.Remove(1)
+	data, _ = json.Marshal(set.Members())
+	if set.Size() != len(data) {
+		t.Errorf("set: Size() = %d, serialized length = %d", set.Size(), len(data))
+	}
+}
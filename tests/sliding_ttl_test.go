@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+func TestSlidingTTLByPattern(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.SlidingTTLPatterns = []string{"session:*"}
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.SetString("session:1", "alice", 100*time.Millisecond)
+	c.SetString("config:1", "v1", 100*time.Millisecond)
+
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := c.GetString("session:1"); !ok {
+		t.Fatal("session:1 should still exist")
+	}
+	if _, ok := c.GetString("config:1"); !ok {
+		t.Fatal("config:1 should still exist")
+	}
+
+	// session:* 读取后过期时间重置为完整TTL，config:* 保持原过期时间
+	if ttl, _ := c.TTL("session:1"); ttl < 80*time.Millisecond {
+		t.Errorf("session:1 TTL should be refreshed on read, got %v", ttl)
+	}
+	if ttl, _ := c.TTL("config:1"); ttl > 50*time.Millisecond {
+		t.Errorf("config:1 TTL must not be refreshed on read, got %v", ttl)
+	}
+
+	// 持续读取使滑动键存活超过原始TTL
+	for i := 0; i < 4; i++ {
+		time.Sleep(40 * time.Millisecond)
+		if _, ok := c.GetString("session:1"); !ok {
+			t.Fatalf("session:1 expired despite being read (round %d)", i)
+		}
+	}
+	if _, ok := c.GetString("config:1"); ok {
+		t.Error("config:1 should have expired at its fixed deadline")
+	}
+}
+
+func TestSlidingTTLIgnoresKeysWithoutTTL(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.SlidingTTLPatterns = []string{"session:*"}
+	c := scache.New(cfg)
+	defer c.Close()
+
+	c.SetString("session:forever", "x")
+	c.GetString("session:forever")
+	if ttl, ok := c.TTL("session:forever"); !ok || ttl > 0 {
+		t.Errorf("Key without TTL must stay persistent after read, got %v, %v", ttl, ok)
+	}
+}
+
+func TestSlidingTTLInvalidPattern(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.SlidingTTLPatterns = []string{"session:["}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected validation error for malformed sliding ttl pattern")
+	}
+}
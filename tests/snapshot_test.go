@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/storage"
+)
+
+// ==================== 流式导出/导入测试 ====================
+
+func newSnapshotTestCache() *scache.LocalCache {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Minute
+	return scache.New(cfg)
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	src := newSnapshotTestCache()
+	src.SetString("str", "hello", time.Hour)
+	src.SetString("permanent", "forever")
+	src.SetList("list", []interface{}{"a", "b"}, time.Hour)
+	src.SetHash("hash", map[string]interface{}{"name": "Alice"})
+	src.SetString("expired", "gone", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(src.Export(writer))
+	}()
+
+	dst := newSnapshotTestCache()
+	if err := dst.Import(reader); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if dst.Size() != 4 {
+		t.Errorf("Expected 4 live keys after import, got %d", dst.Size())
+	}
+	if v, _ := dst.GetString("str"); v != "hello" {
+		t.Errorf("Expected 'hello', got %q", v)
+	}
+	if list, _ := dst.GetList("list"); len(list) != 2 || list[1] != "b" {
+		t.Errorf("Unexpected list: %v", list)
+	}
+	if hash, _ := dst.GetHash("hash"); hash["name"] != "Alice" {
+		t.Errorf("Unexpected hash: %v", hash)
+	}
+	if dst.Exists("expired") {
+		t.Error("Expired key should not be imported")
+	}
+	if ttl, _ := dst.TTL("str"); ttl <= 59*time.Minute {
+		t.Errorf("TTL should be preserved, got %v", ttl)
+	}
+	if ttl, _ := dst.TTL("permanent"); ttl != -1 {
+		t.Errorf("Permanent key should stay permanent, got %v", ttl)
+	}
+}
+
+// writeFrame 写入一条长度前缀数据
+func writeFrame(buf *bytes.Buffer, body string) {
+	binary.Write(buf, binary.BigEndian, uint32(len(body)))
+	buf.WriteString(body)
+}
+
+func TestImportMigratesLegacySnapshot(t *testing.T) {
+	// 模拟版本1的记录使用name作为键字段，迁移时改为key
+	scache.RegisterSnapshotMigration("1", func(record []byte) ([]byte, error) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(record, &fields); err != nil {
+			return nil, err
+		}
+		if name, ok := fields["name"]; ok {
+			fields["key"] = name
+			delete(fields, "name")
+		}
+		return json.Marshal(fields)
+	})
+	defer scache.RegisterSnapshotMigration("1", func(record []byte) ([]byte, error) { return record, nil })
+
+	// 版本1没有版本头
+	var buf bytes.Buffer
+	writeFrame(&buf, `{"name":"legacy","type":"string","value":"v1"}`)
+
+	c := newSnapshotTestCache()
+	if err := c.Import(&buf); err != nil {
+		t.Fatalf("Import of v1 snapshot failed: %v", err)
+	}
+	if v, ok := c.GetString("legacy"); !ok || v != "v1" {
+		t.Errorf("Expected migrated key legacy=v1, got %q, %v", v, ok)
+	}
+}
+
+func TestImportRejectsUnknownSnapshotVersion(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("SCSN")
+	writeFrame(&buf, `{"version":"99"}`)
+	writeFrame(&buf, `{"key":"k","type":"string","value":"v"}`)
+
+	err := newSnapshotTestCache().Import(&buf)
+	if err == nil || !strings.Contains(err.Error(), "unsupported snapshot version") {
+		t.Fatalf("Expected unsupported version error, got %v", err)
+	}
+}
+
+func TestExportWritesVersionHeader(t *testing.T) {
+	src := newSnapshotTestCache()
+	src.SetString("k", "v")
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("SCSN")) {
+		t.Fatal("Expected export to start with the snapshot magic")
+	}
+
+	header := buf.Bytes()[4:]
+	size := binary.BigEndian.Uint32(header)
+	var decoded struct{ Version string }
+	json.Unmarshal(header[4:4+size], &decoded)
+	if decoded.Version != storage.SnapshotVersion {
+		t.Errorf("Expected version %s, got %q", storage.SnapshotVersion, decoded.Version)
+	}
+}
+
+// heapSamplingWriter 丢弃写入数据并周期性采样存活堆内存
+type heapSamplingWriter struct {
+	writes  int
+	maxHeap uint64
+}
+
+func (w *heapSamplingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes%500 == 0 {
+		if heap := heapInUse(); heap > w.maxHeap {
+			w.maxHeap = heap
+		}
+	}
+	return len(p), nil
+}
+
+func TestExportLargeCacheBoundedMemory(t *testing.T) {
+	src := newSnapshotTestCache()
+
+	const total, valueSize = 20000, 1024
+	value := strings.Repeat("x", valueSize)
+	for i := 0; i < total; i++ {
+		src.SetString(fmt.Sprintf("key:%d", i), value)
+	}
+
+	baseline := heapInUse()
+	writer := &heapSamplingWriter{}
+	if err := src.Export(writer); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	// 导出过程中存活堆内存增长应远小于数据总量
+	dataSize := uint64(total * valueSize)
+	if writer.maxHeap > baseline && writer.maxHeap-baseline > dataSize/4 {
+		t.Errorf("Export heap growth %d exceeds bound %d", writer.maxHeap-baseline, dataSize/4)
+	}
+
+	// 通过管道完整往返
+	reader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.CloseWithError(src.Export(pipeWriter))
+	}()
+
+	dst := newSnapshotTestCache()
+	if err := dst.Import(reader); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if dst.Size() != total {
+		t.Errorf("Expected %d keys after round trip, got %d", total, dst.Size())
+	}
+	runtime.KeepAlive(src)
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/interfaces"
+	"github.com/scache-io/scache/pkg/spillover"
+	"github.com/scache-io/scache/types"
+)
+
+func TestOnEvictCallback(t *testing.T) {
+	var evicted []string
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.OnEvict = func(key string, obj interfaces.DataObject) {
+		// 回调在释放锁之后执行，对象仍可读取
+		if v := obj.(*types.StringObject).Value(); v != "v-"+key {
+			t.Errorf("Evicted object for %s has value %q", key, v)
+		}
+		evicted = append(evicted, key)
+	}
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.SetString(key, "v-"+key)
+	}
+	if len(evicted) != 2 || evicted[0] != "a" || evicted[1] != "b" {
+		t.Errorf("Expected a and b to be evicted in order, got %v", evicted)
+	}
+}
+
+func TestSpilloverRestoresEvictedKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill.log")
+	spill, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer spill.Close()
+
+	cfg := config.DefaultEngineConfig()
+	cfg.MaxSize = 2
+	cfg.BackgroundCleanupInterval = time.Minute
+	cfg.OnEvict = spill.OnEvict
+	c := cache.NewLocalCache(cfg)
+	defer c.Close()
+
+	c.SetString("a", "alpha", time.Hour)
+	c.SetHash("b", map[string]interface{}{"name": "bravo"})
+	c.SetString("c", "charlie")
+	c.SetString("d", "delta")
+
+	if c.Exists("a") || c.Exists("b") {
+		t.Fatal("Expected a and b to be evicted")
+	}
+	if spill.Len() != 2 {
+		t.Fatalf("Expected 2 spilled keys, got %d", spill.Len())
+	}
+
+	// 未命中时从溢出文件取回并写回缓存
+	if ok, err := spill.Restore(c.GetEngine(), "a"); !ok || err != nil {
+		t.Fatalf("Restore(a) = %v, %v", ok, err)
+	}
+	if v, ok := c.GetString("a"); !ok || v != "alpha" {
+		t.Errorf("Expected restored a=alpha, got %q, %v", v, ok)
+	}
+	if ttl, _ := c.TTL("a"); ttl <= 59*time.Minute {
+		t.Errorf("Expected TTL to survive spillover, got %v", ttl)
+	}
+	if _, ok, _ := spill.Load("a"); ok {
+		t.Error("Restored key should be removed from the spillover index")
+	}
+	if err := spill.Err(); err != nil {
+		t.Errorf("Unexpected spill error: %v", err)
+	}
+
+	// 重新打开文件后仍可取回
+	spill.Close()
+	reopened, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	obj, ok, err := reopened.Load("b")
+	if !ok || err != nil {
+		t.Fatalf("Load(b) after reopen = %v, %v", ok, err)
+	}
+	if name, _ := obj.(*types.HashObject).Get("name"); name != "bravo" {
+		t.Errorf("Expected b.name=bravo, got %v", name)
+	}
+}
+
+func TestSpilloverLoadSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill.log")
+	spill, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		spill.OnEvict(key, types.NewStringObject("v-"+key, 0))
+	}
+	if _, ok, err := spill.Load("a"); !ok || err != nil {
+		t.Fatalf("Load(a) = %v, %v", ok, err)
+	}
+	spill.Close()
+
+	// 取回的键写入了删除记录，重新打开后不会再被索引
+	reopened, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if _, ok, _ := reopened.Load("a"); ok {
+		t.Error("Loaded key should not come back after reopen")
+	}
+	if reopened.Len() != 2 {
+		t.Errorf("Expected 2 spilled keys after reopen, got %d", reopened.Len())
+	}
+	reopened.Close()
+}
+
+func TestSpilloverCompactsOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill.log")
+	spill, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		spill.OnEvict(fmt.Sprintf("key:%d", i), types.NewStringObject("value", 0))
+	}
+	for i := 0; i < 90; i++ {
+		spill.Load(fmt.Sprintf("key:%d", i))
+	}
+	spill.Close()
+	before, _ := os.Stat(path)
+
+	// 失效记录多于有效记录，重新打开时重写文件
+	reopened, err := spillover.Open(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+	after, _ := os.Stat(path)
+	if after.Size()*5 > before.Size() {
+		t.Errorf("Expected the file to shrink after compaction, %d -> %d bytes", before.Size(), after.Size())
+	}
+
+	for i := 90; i < 100; i++ {
+		obj, ok, err := reopened.Load(fmt.Sprintf("key:%d", i))
+		if !ok || err != nil || obj.(*types.StringObject).Value() != "value" {
+			t.Errorf("Load(key:%d) after compaction = %v, %v", i, ok, err)
+		}
+	}
+	if reopened.Len() != 0 {
+		t.Errorf("Expected no spilled keys left, got %d", reopened.Len())
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/scache-io/scache/cache"
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/types"
+)
+
+// xorTransform 可逆的XOR转换，编码和解码相同
+func xorTransform(data []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[i] = b ^ 0x5a
+	}
+	return out, nil
+}
+
+// newAESTransformer 基于AES-GCM的加密转换，随机nonce前置在密文中
+func newAESTransformer(t *testing.T) (encode, decode func([]byte) ([]byte, error)) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode = func(plain []byte) ([]byte, error) {
+		nonce := make([]byte, gcm.NonceSize())
+		rand.Read(nonce)
+		return gcm.Seal(nonce, nonce, plain, nil), nil
+	}
+	decode = func(sealed []byte) ([]byte, error) {
+		if len(sealed) < gcm.NonceSize() {
+			return nil, errors.New("ciphertext too short")
+		}
+		nonce, data := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+		return gcm.Open(nil, nonce, data, nil)
+	}
+	return encode, decode
+}
+
+// rawValue 直接读取引擎中存储的字符串
+func rawValue(t *testing.T, c *cache.LocalCache, key string) string {
+	t.Helper()
+	obj, ok := c.GetEngine().Get(key)
+	if !ok {
+		t.Fatalf("Key %s not found in engine", key)
+	}
+	return obj.(*types.StringObject).Value()
+}
+
+func TestValueTransformerXOR(t *testing.T) {
+	c := cache.NewLocalCache(config.DefaultEngineConfig().WithValueTransformer(xorTransform, xorTransform))
+	defer c.Close()
+
+	c.SetString("secret", "plaintext")
+	if raw := rawValue(t, c, "secret"); raw == "plaintext" {
+		t.Error("Value should be stored transformed")
+	}
+	if v, ok := c.GetString("secret"); !ok || v != "plaintext" {
+		t.Errorf("GetString = %q, %v, want plaintext", v, ok)
+	}
+
+	c.SetString("count", "42")
+	if n, _, err := c.GetInt("count"); err != nil || n != 42 {
+		t.Errorf("GetInt = %d, %v", n, err)
+	}
+
+	// 确定性转换下条件重命名按明文比较
+	if !c.RenameIf("secret", "moved", "plaintext") {
+		t.Error("RenameIf should match the plaintext value")
+	}
+}
+
+func TestValueTransformerAES(t *testing.T) {
+	encode, decode := newAESTransformer(t)
+	c := cache.NewLocalCache(config.DefaultEngineConfig().WithValueTransformer(encode, decode))
+	defer c.Close()
+
+	type account struct {
+		Name  string `json:"name"`
+		Token string `json:"token"`
+	}
+
+	if err := c.Store("acct", account{Name: "alice", Token: "s3cr3t"}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if raw := rawValue(t, c, "acct"); raw == `{"name":"alice","token":"s3cr3t"}` {
+		t.Error("Struct should be stored encrypted")
+	}
+
+	var got account
+	if err := c.Load("acct", &got); err != nil || got.Token != "s3cr3t" {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+	if typed, _, err := cache.GetStruct[account](c, "acct"); err != nil || typed.Name != "alice" {
+		t.Errorf("GetStruct = %+v, %v", typed, err)
+	}
+
+	c.SetString("plain", "hello")
+	if v, ok := c.GetStringAndDelete("plain"); !ok || v != "hello" {
+		t.Errorf("GetStringAndDelete = %q, %v", v, ok)
+	}
+}
+
+func TestValueTransformerRequiresBothFuncs(t *testing.T) {
+	cfg := config.DefaultEngineConfig().WithValueTransformer(xorTransform, nil)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate should reject a transformer without decode")
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func newTwoPhaseEngine() *storage.StorageEngine {
+	cfg := config.DefaultEngineConfig()
+	cfg.BackgroundCleanupInterval = time.Hour
+	cfg.TwoPhaseDelete = true
+	return storage.NewStorageEngine(cfg).(*storage.StorageEngine)
+}
+
+func TestTwoPhaseDeleteMarksExpiredReads(t *testing.T) {
+	engine := newTwoPhaseEngine()
+	defer engine.Close()
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		engine.Set(fmt.Sprintf("key-%d", i), types.NewStringObject("v", time.Millisecond))
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// 并发读取过期键只做标记，键仍留在存储中
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if _, ok := engine.Get(fmt.Sprintf("key-%d", i)); ok {
+					t.Error("Expired key should not be returned")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if engine.Size() != n {
+		t.Fatalf("Expected marked keys to remain until sweep, size %d", engine.Size())
+	}
+	stats := engine.Stats().(map[string]interface{})
+	if got := stats["pending_sweep"]; got != n {
+		t.Errorf("Expected %d keys pending sweep (each marked once), got %v", n, got)
+	}
+	if got := stats["expirations"]; got != int64(0) {
+		t.Errorf("Expected no expirations before sweep, got %v", got)
+	}
+
+	if removed := engine.Sweep(); removed != n {
+		t.Errorf("Expected sweep to remove %d keys, got %d", n, removed)
+	}
+	if engine.Size() != 0 {
+		t.Errorf("Expected empty engine after sweep, size %d", engine.Size())
+	}
+	stats = engine.Stats().(map[string]interface{})
+	if stats["expirations"] != int64(n) || stats["pending_sweep"] != 0 {
+		t.Errorf("Unexpected stats after sweep: expirations %v, pending %v", stats["expirations"], stats["pending_sweep"])
+	}
+}
+
+func TestTwoPhaseDeleteSweepSkipsRewrittenKeys(t *testing.T) {
+	engine := newTwoPhaseEngine()
+	defer engine.Close()
+
+	engine.Set("k", types.NewStringObject("old", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	engine.Get("k")
+
+	// 标记后重新写入的键不应被清理
+	engine.Set("k", types.NewStringObject("new", 0))
+	if removed := engine.Sweep(); removed != 0 {
+		t.Errorf("Expected rewritten key to survive sweep, removed %d", removed)
+	}
+	if obj, ok := engine.Get("k"); !ok || obj.(*types.StringObject).Value() != "new" {
+		t.Error("Rewritten key should remain readable")
+	}
+}
+
+func TestTwoPhaseDeleteRequiresBackgroundCleanup(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.TwoPhaseDelete = true
+	engine := storage.NewStorageEngine(cfg)
+	defer engine.Close()
+
+	engine.Set("k", types.NewStringObject("v", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	engine.Get("k")
+
+	// 没有后台清理时退化为立即删除，避免标记的键永远留在存储中
+	if engine.Size() != 0 {
+		t.Errorf("Expected immediate delete without background cleanup, size %d", engine.Size())
+	}
+}
+
+func TestExpireDoesNotReviveExpiredKeys(t *testing.T) {
+	engine := newTwoPhaseEngine()
+	defer engine.Close()
+
+	engine.Set("marked", types.NewStringObject("v", time.Millisecond))
+	engine.Set("unread", types.NewStringObject("v", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// Get已报告未命中并标记的键，Expire不能让它重新出现
+	if _, ok := engine.Get("marked"); ok {
+		t.Fatal("Expired key should not be returned")
+	}
+	for _, key := range []string{"marked", "unread"} {
+		if engine.Expire(key, time.Hour) {
+			t.Errorf("Expire(%s) on an expired key should return false", key)
+		}
+		if engine.Exists(key) {
+			t.Errorf("Expire(%s) should not revive the key", key)
+		}
+	}
+
+	if removed := engine.Sweep(); removed != 2 {
+		t.Errorf("Expected sweep to remove both expired keys, got %d", removed)
+	}
+}
@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+// ==================== 类型化读取测试 ====================
+
+func TestGetStringE(t *testing.T) {
+	scache.SetString("typed:str", "hello", time.Minute)
+	scache.SetString("typed:empty", "", time.Minute)
+	scache.SetHash("typed:strhash", map[string]interface{}{"a": 1}, time.Minute)
+	defer func() {
+		scache.Delete("typed:str")
+		scache.Delete("typed:empty")
+		scache.Delete("typed:strhash")
+	}()
+
+	value, found, err := scache.GetStringE("typed:str")
+	if err != nil || !found || value != "hello" {
+		t.Errorf("Expected (hello, true, nil), got (%q, %v, %v)", value, found, err)
+	}
+	if value, found, err := scache.GetStringE("typed:empty"); err != nil || !found || value != "" {
+		t.Errorf("Expected empty string hit, got (%q, %v, %v)", value, found, err)
+	}
+
+	if _, found, err := scache.GetStringE("typed:missing"); found || err != nil {
+		t.Errorf("Expected miss without error, got found=%v err=%v", found, err)
+	}
+
+	// GetString把类型不匹配当作未命中，GetStringE返回错误
+	if _, found, err := scache.GetStringE("typed:strhash"); !found || !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for hash value, got found=%v err=%v", found, err)
+	}
+	if _, found := scache.GetString("typed:strhash"); found {
+		t.Error("GetString should keep reporting a type mismatch as a miss")
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	scache.SetString("typed:int", "42", time.Minute)
+	scache.SetString("typed:notint", "abc", time.Minute)
+	scache.SetList("typed:list", []interface{}{1}, time.Minute)
+	defer func() {
+		scache.Delete("typed:int")
+		scache.Delete("typed:notint")
+		scache.Delete("typed:list")
+	}()
+
+	value, found, err := scache.GetInt("typed:int")
+	if err != nil || !found || value != 42 {
+		t.Errorf("Expected (42, true, nil), got (%d, %v, %v)", value, found, err)
+	}
+
+	if _, found, err := scache.GetInt("typed:missing"); found || err != nil {
+		t.Errorf("Expected miss without error, got found=%v err=%v", found, err)
+	}
+
+	if _, _, err := scache.GetInt("typed:notint"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for non-numeric string, got %v", err)
+	}
+	if _, _, err := scache.GetInt("typed:list"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for list value, got %v", err)
+	}
+}
+
+func TestGetStruct(t *testing.T) {
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	scache.Store("typed:user", User{ID: 7, Name: "Alice"}, time.Minute)
+	scache.SetString("typed:badjson", "not json", time.Minute)
+	scache.SetHash("typed:hash", map[string]interface{}{"a": 1}, time.Minute)
+	defer func() {
+		scache.Delete("typed:user")
+		scache.Delete("typed:badjson")
+		scache.Delete("typed:hash")
+	}()
+
+	user, found, err := scache.GetStruct[User]("typed:user")
+	if err != nil || !found || user.ID != 7 || user.Name != "Alice" {
+		t.Errorf("Expected Alice, got (%+v, %v, %v)", user, found, err)
+	}
+
+	if _, found, err := scache.GetStruct[User]("typed:missing"); found || err != nil {
+		t.Errorf("Expected miss without error, got found=%v err=%v", found, err)
+	}
+
+	if _, _, err := scache.GetStruct[User]("typed:badjson"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for invalid JSON, got %v", err)
+	}
+	if _, _, err := scache.GetStruct[User]("typed:hash"); !errors.Is(err, scache.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for hash value, got %v", err)
+	}
+}
+
+func TestLoadErrorsAreClassified(t *testing.T) {
+	var dest struct{}
+	if err := scache.Load("typed:missing", &dest); !errors.Is(err, scache.ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestLoadPreservesIntegerPrecision(t *testing.T) {
+	type Counter struct {
+		ID    int64 `json:"id"`
+		Total int64 `json:"total"`
+	}
+	const big = int64(1)<<62 + 1 // 超出float64可精确表示的范围
+
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.Store("counter", Counter{ID: big, Total: -big})
+
+	var typed Counter
+	if err := cache.Load("counter", &typed); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if typed.ID != big || typed.Total != -big {
+		t.Errorf("int64 fields should round-trip exactly, got %+v", typed)
+	}
+
+	// 默认解码到interface{}时数字变为float64，丢失精度
+	var raw map[string]interface{}
+	cache.Load("counter", &raw)
+	if _, ok := raw["id"].(float64); !ok {
+		t.Errorf("Expected float64 by default, got %T", raw["id"])
+	}
+
+	// 启用UseJSONNumber后保留整数
+	cfg := config.DefaultEngineConfig()
+	cfg.UseJSONNumber = true
+	numbered := scache.New(cfg)
+	numbered.Store("counter", Counter{ID: big, Total: -big})
+
+	raw = nil
+	if err := numbered.Load("counter", &raw); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	num, ok := raw["id"].(json.Number)
+	if !ok {
+		t.Fatalf("Expected json.Number with UseJSONNumber, got %T", raw["id"])
+	}
+	if id, err := num.Int64(); err != nil || id != big {
+		t.Errorf("Expected %d, got %v (err %v)", big, num, err)
+	}
+
+	// Update回调中的当前值同样保留整数
+	err := numbered.Update("counter", 0, func(cur interface{}) (interface{}, error) {
+		total := cur.(map[string]interface{})["total"].(json.Number)
+		if v, _ := total.Int64(); v != -big {
+			t.Errorf("Expected %d in Update, got %v", -big, total)
+		}
+		return cur, nil
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := numbered.Load("counter", &typed); err != nil || typed.Total != -big {
+		t.Errorf("Value rewritten by Update should keep precision, got %+v (err %v)", typed, err)
+	}
+}
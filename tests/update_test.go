@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache"
+	"github.com/scache-io/scache/config"
+)
+
+// ==================== 原子更新测试 ====================
+
+type counterDoc struct {
+	Count int      `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func TestUpdateStructConcurrent(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	const workers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := scache.UpdateStruct(cache, "doc", time.Minute, func(cur *counterDoc) (counterDoc, error) {
+				if cur == nil {
+					return counterDoc{Count: 1, Tags: []string{"x"}}, nil
+				}
+				cur.Count++
+				cur.Tags = append(cur.Tags, "x")
+				return *cur, nil
+			})
+			if err != nil {
+				t.Errorf("UpdateStruct failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var doc counterDoc
+	if err := cache.Load("doc", &doc); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if doc.Count != workers || len(doc.Tags) != workers {
+		t.Errorf("Expected %d applied mutations, got count=%d tags=%d", workers, doc.Count, len(doc.Tags))
+	}
+}
+
+func TestUpdateMissingKeyAndError(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+
+	err := cache.Update("created", time.Minute, func(cur interface{}) (interface{}, error) {
+		if cur != nil {
+			t.Errorf("Expected nil for missing key, got %v", cur)
+		}
+		return map[string]int{"count": 1}, nil
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	err = cache.Update("created", time.Minute, func(cur interface{}) (interface{}, error) {
+		doc := cur.(map[string]interface{})
+		doc["count"] = doc["count"].(float64) + 1
+		return doc, nil
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	var doc counterDoc
+	cache.Load("created", &doc)
+	if doc.Count != 2 {
+		t.Errorf("Expected count 2, got %d", doc.Count)
+	}
+
+	// fn返回错误时不写入
+	failure := errors.New("abort")
+	err = cache.Update("created", time.Minute, func(cur interface{}) (interface{}, error) {
+		return map[string]int{"count": 100}, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected fn error to propagate, got %v", err)
+	}
+	cache.Load("created", &doc)
+	if doc.Count != 2 {
+		t.Errorf("Failed update should not store, got count %d", doc.Count)
+	}
+}
+
+func TestUpdateSerializesNormalizedKeys(t *testing.T) {
+	cfg := config.DefaultEngineConfig()
+	cfg.KeyNormalizer = strings.ToLower
+	cache := scache.New(cfg)
+	defer cache.Close()
+
+	const workers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		key := "counter"
+		if i%2 == 1 {
+			key = "COUNTER"
+		}
+		go func() {
+			defer wg.Done()
+			err := scache.UpdateStruct(cache, key, 0, func(cur *counterDoc) (counterDoc, error) {
+				if cur == nil {
+					return counterDoc{Count: 1}, nil
+				}
+				cur.Count++
+				return *cur, nil
+			})
+			if err != nil {
+				t.Errorf("UpdateStruct failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var doc counterDoc
+	if err := cache.Load("Counter", &doc); err != nil || doc.Count != workers {
+		t.Errorf("Updates through differently cased keys must not be lost, got count=%d err=%v", doc.Count, err)
+	}
+}
+
+func TestUpdateStructAfterClose(t *testing.T) {
+	cache := scache.New(config.DefaultEngineConfig())
+	cache.Close()
+
+	called := false
+	err := scache.UpdateStruct(cache, "doc", 0, func(cur *counterDoc) (counterDoc, error) {
+		called = true
+		return counterDoc{}, nil
+	})
+	if !errors.Is(err, scache.ErrCacheClosed) || called {
+		t.Errorf("UpdateStruct on a closed cache should return ErrCacheClosed without calling fn, got %v (called=%v)", err, called)
+	}
+}
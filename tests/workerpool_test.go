@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scache-io/scache/config"
+	"github.com/scache-io/scache/pkg/workerpool"
+	"github.com/scache-io/scache/storage"
+	"github.com/scache-io/scache/types"
+)
+
+func TestSharedWorkerPoolBoundsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	pool := workerpool.New(4)
+	defer pool.Close()
+
+	engines := make([]*storage.StorageEngine, 0, 100)
+	for i := 0; i < 100; i++ {
+		cfg := config.DefaultEngineConfig()
+		cfg.BackgroundCleanupInterval = 10 * time.Millisecond
+		cfg.WorkerPool = pool
+		engines = append(engines, storage.NewStorageEngine(cfg).(*storage.StorageEngine))
+	}
+	defer func() {
+		for _, engine := range engines {
+			engine.Close()
+		}
+	}()
+
+	// 4个工作goroutine + 1个调度goroutine，而不是100个清理goroutine
+	if delta := runtime.NumGoroutine() - before; delta > 10 {
+		t.Errorf("Expected at most ~5 extra goroutines with a shared pool, got %d", delta)
+	}
+
+	// 共享任务池仍应执行每个引擎的过期清理
+	for _, engine := range engines {
+		engine.Set("short", types.NewStringObject("v", 5*time.Millisecond))
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		remaining := 0
+		for _, engine := range engines {
+			remaining += engine.Size()
+		}
+		if remaining == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expired keys should be cleaned up by the shared pool")
+}
+
+func TestWorkerPoolJobStop(t *testing.T) {
+	pool := workerpool.New(2)
+	defer pool.Close()
+
+	var runs atomic.Int64
+	job := pool.Every(5*time.Millisecond, func() { runs.Add(1) })
+
+	time.Sleep(50 * time.Millisecond)
+	job.Stop()
+	time.Sleep(10 * time.Millisecond)
+	stopped := runs.Load()
+	if stopped == 0 {
+		t.Fatal("Job should have run before Stop")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Errorf("Job should not run after Stop: %d -> %d", stopped, runs.Load())
+	}
+}
@@ -0,0 +1,183 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scache-io/scache/interfaces"
+)
+
+// CircularListObject 固定容量的环形列表，写满后从另一端覆盖最旧的元素
+// 基于环形缓冲区实现，两端的Push/Pop均为O(1)
+type CircularListObject struct {
+	BaseObject
+	buf  []interface{}
+	head int // 第一个元素在buf中的位置
+	n    int // 当前元素个数
+	size int // 按JSON数组计算的字节数，写入和移除元素时增量维护
+	mu   sync.RWMutex
+}
+
+// NewCircularListObject 创建环形列表，values超出容量时只保留最后capacity个
+func NewCircularListObject(capacity int, values []interface{}, ttl time.Duration) *CircularListObject {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	obj := &CircularListObject{
+		BaseObject: *NewBaseObject(interfaces.DataTypeList, ttl),
+		buf:        make([]interface{}, capacity),
+		size:       2, // []
+	}
+	for _, v := range values {
+		obj.pushBack(v)
+	}
+	return obj
+}
+
+// Capacity 返回容量
+func (l *CircularListObject) Capacity() int {
+	return len(l.buf)
+}
+
+// pos 返回第i个元素在buf中的位置
+func (l *CircularListObject) pos(i int) int {
+	return (l.head + i) % len(l.buf)
+}
+
+// pushBack 尾部写入，已满时丢弃头部元素
+func (l *CircularListObject) pushBack(value interface{}) {
+	if l.n == len(l.buf) {
+		l.size += ElementSize(value, l.n-1) - ElementSize(l.buf[l.head], l.n-1)
+		l.buf[l.head] = value
+		l.head = l.pos(1)
+		return
+	}
+	l.buf[l.pos(l.n)] = value
+	l.size += ElementSize(value, l.n)
+	l.n++
+}
+
+// pushFront 头部写入，已满时丢弃尾部元素
+func (l *CircularListObject) pushFront(value interface{}) {
+	if l.n == len(l.buf) {
+		l.size += ElementSize(value, l.n-1) - ElementSize(l.buf[l.pos(l.n-1)], l.n-1)
+	} else {
+		l.size += ElementSize(value, l.n)
+		l.n++
+	}
+	l.head = (l.head - 1 + len(l.buf)) % len(l.buf)
+	l.buf[l.head] = value
+}
+
+// values 按顺序复制元素（调用方持有锁）
+func (l *CircularListObject) values(start, end int) []interface{} {
+	result := make([]interface{}, end-start)
+	for i := range result {
+		result[i] = l.buf[l.pos(start+i)]
+	}
+	return result
+}
+
+// Values 按从头到尾的顺序返回所有值
+func (l *CircularListObject) Values() []interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	l.UpdateAccess()
+	return l.values(0, l.n)
+}
+
+// Push 在尾部添加元素，已满时丢弃头部最旧的元素
+func (l *CircularListObject) Push(value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pushBack(value)
+	l.UpdateModified()
+}
+
+// PushFront 在头部添加元素，已满时丢弃尾部最旧的元素
+func (l *CircularListObject) PushFront(value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pushFront(value)
+	l.UpdateModified()
+}
+
+// Pop 从尾部移除元素
+func (l *CircularListObject) Pop() (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.n == 0 {
+		return nil, false
+	}
+
+	index := l.pos(l.n - 1)
+	value := l.buf[index]
+	l.buf[index] = nil
+	l.n--
+	l.size -= ElementSize(value, l.n)
+	l.UpdateModified()
+	return value, true
+}
+
+// PopFront 从头部移除元素
+func (l *CircularListObject) PopFront() (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.n == 0 {
+		return nil, false
+	}
+
+	value := l.buf[l.head]
+	l.buf[l.head] = nil
+	l.head = l.pos(1)
+	l.n--
+	l.size -= ElementSize(value, l.n)
+	l.UpdateModified()
+	return value, true
+}
+
+// Index 返回指定索引的元素，支持负数索引
+func (l *CircularListObject) Index(index int) (interface{}, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	index, ok := NormalizeIndex(index, l.n)
+	if !ok {
+		return nil, false
+	}
+
+	l.UpdateAccess()
+	return l.buf[l.pos(index)], true
+}
+
+// Range 返回闭区间[start, end]内的元素，支持负数索引
+func (l *CircularListObject) Range(start, end int) []interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	start, end, ok := NormalizeRange(start, end, l.n)
+	if !ok {
+		return []interface{}{}
+	}
+
+	l.UpdateAccess()
+	return l.values(start, end+1)
+}
+
+// Len 返回列表长度
+func (l *CircularListObject) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	l.UpdateAccess()
+	return l.n
+}
+
+// Size Return object size，O(1)返回增量维护的大小
+func (l *CircularListObject) Size() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.size
+}
@@ -1,7 +1,11 @@
 package types
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scache-io/scache/interfaces"
@@ -19,9 +23,11 @@ var (
 
 	listObjectPool = sync.Pool{
 		New: func() interface{} {
+			buf := make([]interface{}, 0, 16)
 			return &ListObject{
 				BaseObject: BaseObject{},
-				values:     make([]interface{}, 0, 16),
+				buf:        buf,
+				values:     buf,
 			}
 		},
 	}
@@ -34,14 +40,27 @@ var (
 			}
 		},
 	}
+
+	setObjectPool = sync.Pool{
+		New: func() interface{} {
+			return &SetObject{
+				BaseObject: BaseObject{},
+				members:    make(map[interface{}]struct{}),
+			}
+		},
+	}
 )
 
 // BaseObject Base object implementation
 type BaseObject struct {
 	dataType  interfaces.DataType
 	expiresAt time.Time
+	ttl       time.Duration // 写入时的TTL，用于滑动过期
 	created   time.Time
-	accessed  time.Time
+	accessed  atomic.Int64 // 最后访问时间（UnixNano），原子更新避免读路径加写锁
+	modified  atomic.Int64 // 最后修改时间（UnixNano），写入时等于创建时间，原地修改时更新
+	accesses  atomic.Int64 // 访问次数
+	tombstone atomic.Bool  // 已过期并标记待清理（两阶段删除）
 	mu        sync.RWMutex
 }
 
@@ -53,12 +72,15 @@ func NewBaseObject(dataType interfaces.DataType, ttl time.Duration) *BaseObject
 		expiresAt = now.Add(ttl)
 	}
 
-	return &BaseObject{
+	obj := &BaseObject{
 		dataType:  dataType,
 		expiresAt: expiresAt,
+		ttl:       max(ttl, 0),
 		created:   now,
-		accessed:  now,
 	}
+	obj.accessed.Store(now.UnixNano())
+	obj.modified.Store(now.UnixNano())
+	return obj
 }
 
 // Type 返回Data type
@@ -73,6 +95,38 @@ func (o *BaseObject) ExpiresAt() time.Time {
 	return o.expiresAt
 }
 
+// SetExpiresAt 原地修改过期时间，零值表示永不过期；滑动过期的窗口随之变为到该时间的剩余时长
+func (o *BaseObject) SetExpiresAt(expiresAt time.Time) {
+	o.SetExpiresAtFrom(expiresAt, time.Now())
+}
+
+// SetExpiresAtFrom 同SetExpiresAt，以now作为当前时间计算滑动过期的窗口（用于注入时钟）
+func (o *BaseObject) SetExpiresAtFrom(expiresAt, now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.expiresAt = expiresAt
+	o.ttl = 0
+	if !expiresAt.IsZero() {
+		o.ttl = max(expiresAt.Sub(now), 0)
+	}
+}
+
+// Slide 将过期时间顺延为当前时间加上写入时的TTL，永不过期的对象不受影响，返回是否顺延
+func (o *BaseObject) Slide() bool {
+	return o.SlideAt(time.Now())
+}
+
+// SlideAt 同Slide，以now作为当前时间（用于注入时钟）
+func (o *BaseObject) SlideAt(now time.Time) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.ttl <= 0 || o.expiresAt.IsZero() {
+		return false
+	}
+	o.expiresAt = now.Add(o.ttl)
+	return true
+}
+
 // IsExpired Check if expired
 func (o *BaseObject) IsExpired() bool {
 	o.mu.RLock()
@@ -88,11 +142,46 @@ func isExpiredUnsafe(expiresAt time.Time) bool {
 	return time.Now().After(expiresAt)
 }
 
-// UpdateAccess 更新访问时间
+// UpdateAccess 更新访问时间和访问次数（无锁）
 func (o *BaseObject) UpdateAccess() {
-	o.mu.Lock()
-	defer o.mu.Unlock()
-	o.accessed = time.Now()
+	o.accessed.Store(time.Now().UnixNano())
+	o.accesses.Add(1)
+}
+
+// UpdateModified 记录一次原地修改：更新最后修改时间并计为一次访问
+func (o *BaseObject) UpdateModified() {
+	o.modified.Store(time.Now().UnixNano())
+	o.UpdateAccess()
+}
+
+// ModifiedAt 返回最后修改时间（写入或最近一次原地修改）
+func (o *BaseObject) ModifiedAt() time.Time {
+	return time.Unix(0, o.modified.Load())
+}
+
+// SetModifiedAt 设置最后修改时间，用于复制对象时保留来源的修改时间
+func (o *BaseObject) SetModifiedAt(t time.Time) {
+	o.modified.Store(t.UnixNano())
+}
+
+// AccessedAt 返回最后访问时间
+func (o *BaseObject) AccessedAt() time.Time {
+	return time.Unix(0, o.accessed.Load())
+}
+
+// AccessCount 返回访问次数
+func (o *BaseObject) AccessCount() int64 {
+	return o.accesses.Load()
+}
+
+// MarkTombstone 原子标记对象待清理，返回是否由本次调用标记
+func (o *BaseObject) MarkTombstone() bool {
+	return o.tombstone.CompareAndSwap(false, true)
+}
+
+// Tombstoned 返回对象是否已标记待清理
+func (o *BaseObject) Tombstoned() bool {
+	return o.tombstone.Load()
 }
 
 // CreatedAt 返回创建时间
@@ -106,8 +195,12 @@ func (o *BaseObject) CreatedAt() time.Time {
 func (o *BaseObject) reset() {
 	o.dataType = ""
 	o.expiresAt = time.Time{}
+	o.ttl = 0
 	o.created = time.Time{}
-	o.accessed = time.Time{}
+	o.accessed.Store(0)
+	o.modified.Store(0)
+	o.accesses.Store(0)
+	o.tombstone.Store(false)
 }
 
 // StringObject String object实现
@@ -140,8 +233,10 @@ func (s *StringObject) init(value string, ttl time.Duration) {
 
 	s.BaseObject.dataType = interfaces.DataTypeString
 	s.BaseObject.expiresAt = expiresAt
+	s.BaseObject.ttl = max(ttl, 0)
 	s.BaseObject.created = now
-	s.BaseObject.accessed = now
+	s.BaseObject.accessed.Store(now.UnixNano())
+	s.BaseObject.modified.Store(now.UnixNano())
 	s.value = value
 }
 
@@ -163,7 +258,7 @@ func (s *StringObject) Set(value string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.value = value
-	s.UpdateAccess()
+	s.UpdateModified()
 }
 
 // StructObject Struct object实现（复用StringObject，增加JSON支持）
@@ -188,7 +283,7 @@ func (s *StructObject) Set(data string) {
 	s.StringObject.Set(data)
 }
 
-// Size Return object size（字节）
+// Size Return object size（字节，即字符串长度）
 func (s *StringObject) Size() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -209,9 +304,13 @@ func (s *StringObject) Clear() {
 }
 
 // ListObject List object实现
+// values是buf[head:]上的视图，头部预留空位使PushFront/PopFront与尾部操作一样均摊O(1)
 type ListObject struct {
 	BaseObject
+	buf    []interface{} // 底层数组
+	head   int           // 第一个元素在buf中的位置
 	values []interface{}
+	size   int // 按JSON数组计算的字节数，写入和移除元素时增量维护
 	mu     sync.RWMutex
 }
 
@@ -238,10 +337,31 @@ func (l *ListObject) init(values []interface{}, ttl time.Duration) {
 
 	l.BaseObject.dataType = interfaces.DataTypeList
 	l.BaseObject.expiresAt = expiresAt
+	l.BaseObject.ttl = max(ttl, 0)
 	l.BaseObject.created = now
-	l.BaseObject.accessed = now
-	l.values = l.values[:0]
+	l.BaseObject.accessed.Store(now.UnixNano())
+	l.BaseObject.modified.Store(now.UnixNano())
+	l.resetValues()
 	l.values = append(l.values, values...)
+	l.syncBuf()
+	l.size = listSize(l.values)
+}
+
+// resetValues 清空元素并回到底层数组起点，保留容量以便复用
+func (l *ListObject) resetValues() {
+	clear(l.buf[:cap(l.buf)])
+	l.buf = l.buf[:0]
+	l.head = 0
+	l.values = l.buf
+	l.size = 2 // []
+}
+
+// syncBuf 尾部append导致重新分配后，以新数组作为底层数组
+func (l *ListObject) syncBuf() {
+	if cap(l.values) != cap(l.buf)-l.head {
+		l.buf = l.values
+		l.head = 0
+	}
 }
 
 // NewListObject 创建List object（从对象池获取）
@@ -265,8 +385,48 @@ func (l *ListObject) Values() []interface{} {
 func (l *ListObject) Push(value interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.size += ElementSize(value, len(l.values))
 	l.values = append(l.values, value)
-	l.UpdateAccess()
+	l.syncBuf()
+	l.UpdateModified()
+}
+
+// PushFront 在列表头部添加元素，头部没有空位时按当前长度预留空位后重新分配
+func (l *ListObject) PushFront(value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := len(l.values)
+	if l.head == 0 {
+		slack := max(n, 4)
+		buf := make([]interface{}, slack+n, slack+cap(l.values))
+		copy(buf[slack:], l.values)
+		l.buf = buf
+		l.head = slack
+	}
+	l.head--
+	l.buf[l.head] = value
+	l.values = l.buf[l.head : l.head+n+1]
+	l.size += ElementSize(value, n)
+	l.UpdateModified()
+}
+
+// PopFront 从列表头部移除元素
+func (l *ListObject) PopFront() (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.values) == 0 {
+		return nil, false
+	}
+
+	value := l.values[0]
+	l.values[0] = nil
+	l.values = l.values[1:]
+	l.head++
+	l.size -= ElementSize(value, len(l.values))
+	l.UpdateModified()
+	return value, true
 }
 
 // Pop 从列表末尾移除元素
@@ -280,17 +440,52 @@ func (l *ListObject) Pop() (interface{}, bool) {
 
 	index := len(l.values) - 1
 	value := l.values[index]
+	l.values[index] = nil
 	l.values = l.values[:index]
-	l.UpdateAccess()
+	l.size -= ElementSize(value, len(l.values))
+	l.UpdateModified()
 	return value, true
 }
 
-// Index 返回指定索引的元素
+// NormalizeIndex 将索引规范化为[0, length)范围，负数从末尾计数（-1表示最后一个元素）
+func NormalizeIndex(index, length int) (int, bool) {
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		return 0, false
+	}
+	return index, true
+}
+
+// NormalizeRange 按Redis语义规范化闭区间[start, end]，负数从末尾计数
+// start超出长度或区间为空时返回false，end超出长度时截断到最后一个元素
+func NormalizeRange(start, end, length int) (int, int, bool) {
+	if start < 0 {
+		start += length
+	}
+	if end < 0 {
+		end += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end >= length {
+		end = length - 1
+	}
+	if start >= length || start > end {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// Index 返回指定索引的元素，支持负数索引
 func (l *ListObject) Index(index int) (interface{}, bool) {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
-	if index < 0 || index >= len(l.values) {
+	index, ok := NormalizeIndex(index, len(l.values))
+	if !ok {
 		return nil, false
 	}
 
@@ -298,19 +493,14 @@ func (l *ListObject) Index(index int) (interface{}, bool) {
 	return l.values[index], true
 }
 
-// Range 返回指定范围的元素
+// Range 返回闭区间[start, end]内的元素，支持负数索引
 func (l *ListObject) Range(start, end int) []interface{} {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
-	if start < 0 {
-		start = 0
-	}
-	if end >= len(l.values) {
-		end = len(l.values) - 1
-	}
-	if start > end {
-		return nil
+	start, end, ok := NormalizeRange(start, end, len(l.values))
+	if !ok {
+		return []interface{}{}
 	}
 
 	l.UpdateAccess()
@@ -328,18 +518,18 @@ func (l *ListObject) Len() int {
 	return len(l.values)
 }
 
-// Size Return object size
+// Size Return object size，O(1)返回增量维护的大小
 func (l *ListObject) Size() int {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
-	return len(l.values) * 8 // 估算每个元素8字节
+	return l.size
 }
 
 // Reset 重置对象以便复用
 func (l *ListObject) Reset() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	l.values = l.values[:0]
+	l.resetValues()
 	l.BaseObject.reset()
 }
 
@@ -352,6 +542,7 @@ func (l *ListObject) Clear() {
 type HashObject struct {
 	BaseObject
 	fields map[string]interface{}
+	size   int // 按JSON对象计算的字节数，写入和删除字段时增量维护
 	mu     sync.RWMutex
 }
 
@@ -378,8 +569,10 @@ func (h *HashObject) init(fields map[string]interface{}, ttl time.Duration) {
 
 	h.BaseObject.dataType = interfaces.DataTypeHash
 	h.BaseObject.expiresAt = expiresAt
+	h.BaseObject.ttl = max(ttl, 0)
 	h.BaseObject.created = now
-	h.BaseObject.accessed = now
+	h.BaseObject.accessed.Store(now.UnixNano())
+	h.BaseObject.modified.Store(now.UnixNano())
 	// Clear existing fields
 	for k := range h.fields {
 		delete(h.fields, k)
@@ -390,6 +583,7 @@ func (h *HashObject) init(fields map[string]interface{}, ttl time.Duration) {
 			h.fields[k] = v
 		}
 	}
+	h.size = mapSize(h.fields)
 }
 
 // NewHashObject 创建Hash object（从对象池获取）
@@ -427,8 +621,13 @@ func (h *HashObject) Get(field string) (interface{}, bool) {
 func (h *HashObject) Set(field string, value interface{}) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	if old, exists := h.fields[field]; exists {
+		h.size += ValueSize(value) - ValueSize(old)
+	} else {
+		h.size += fieldSize(field, value, len(h.fields))
+	}
 	h.fields[field] = value
-	h.UpdateAccess()
+	h.UpdateModified()
 }
 
 // Delete 删除字段
@@ -436,9 +635,10 @@ func (h *HashObject) Delete(field string) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if _, exists := h.fields[field]; exists {
+	if old, exists := h.fields[field]; exists {
 		delete(h.fields, field)
-		h.UpdateAccess()
+		h.size -= fieldSize(field, old, len(h.fields))
+		h.UpdateModified()
 		return true
 	}
 	return false
@@ -452,16 +652,11 @@ func (h *HashObject) Len() int {
 	return len(h.fields)
 }
 
-// Size Return object size
+// Size Return object size，O(1)返回增量维护的大小
 func (h *HashObject) Size() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-
-	size := 0
-	for k := range h.fields {
-		size += len(k) + 8 // 键长度 + 值估算
-	}
-	return size
+	return h.size
 }
 
 // Reset 重置对象以便复用
@@ -472,6 +667,7 @@ func (h *HashObject) Reset() {
 	for k := range h.fields {
 		delete(h.fields, k)
 	}
+	h.size = 2 // {}
 	h.BaseObject.reset()
 }
 
@@ -479,3 +675,187 @@ func (h *HashObject) Reset() {
 func (h *HashObject) Clear() {
 	h.Reset()
 }
+
+// SetObject Set object实现，成员无序且不重复
+type SetObject struct {
+	BaseObject
+	members map[interface{}]struct{}
+	size    int // 按JSON数组计算的字节数，添加和移除成员时增量维护
+	mu      sync.RWMutex
+}
+
+// AcquireSetObject 从对象池获取 SetObject
+func AcquireSetObject(members []interface{}, ttl time.Duration) *SetObject {
+	obj := setObjectPool.Get().(*SetObject)
+	obj.init(members, ttl)
+	return obj
+}
+
+// ReleaseSetObject 将对象返回到对象池
+func ReleaseSetObject(obj *SetObject) {
+	obj.Reset()
+	setObjectPool.Put(obj)
+}
+
+// init 初始化对象（用于对象池复用），不可比较的成员被忽略
+func (s *SetObject) init(members []interface{}, ttl time.Duration) {
+	now := time.Now()
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	s.BaseObject.dataType = interfaces.DataTypeSet
+	s.BaseObject.expiresAt = expiresAt
+	s.BaseObject.ttl = max(ttl, 0)
+	s.BaseObject.created = now
+	s.BaseObject.accessed.Store(now.UnixNano())
+	s.BaseObject.modified.Store(now.UnixNano())
+	for m := range s.members {
+		delete(s.members, m)
+	}
+	for _, m := range members {
+		if IsSetMember(m) {
+			s.members[m] = struct{}{}
+		}
+	}
+	s.size = setSize(s.members)
+}
+
+// NewSetObject 创建Set object（从对象池获取）
+func NewSetObject(members []interface{}, ttl time.Duration) *SetObject {
+	return AcquireSetObject(members, ttl)
+}
+
+// IsSetMember 检查值能否作为Set成员（可比较，不含切片、map等）
+func IsSetMember(member interface{}) bool {
+	if member == nil {
+		return true
+	}
+	return reflect.TypeOf(member).Comparable() && comparableValue(reflect.ValueOf(member))
+}
+
+// comparableValue 递归检查接口字段与数组元素的动态类型，避免结构体中嵌套切片导致map写入panic
+func comparableValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		return v.Elem().Type().Comparable() && comparableValue(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !comparableValue(v.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !comparableValue(v.Index(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Add 添加成员，返回是否为新成员；不可比较的成员不会被添加
+func (s *SetObject) Add(member interface{}) bool {
+	if !IsSetMember(member) {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.members[member]; exists {
+		return false
+	}
+	s.size += ElementSize(member, len(s.members))
+	s.members[member] = struct{}{}
+	s.UpdateModified()
+	return true
+}
+
+// Remove 移除成员，返回成员是否存在
+func (s *SetObject) Remove(member interface{}) bool {
+	if !IsSetMember(member) {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.members[member]; exists {
+		delete(s.members, member)
+		s.size -= ElementSize(member, len(s.members))
+		s.UpdateModified()
+		return true
+	}
+	return false
+}
+
+// Contains 检查成员是否存在
+func (s *SetObject) Contains(member interface{}) bool {
+	if !IsSetMember(member) {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.UpdateAccess()
+	_, exists := s.members[member]
+	return exists
+}
+
+// Members 返回所有成员的副本，按类型与格式化后的值排序以保证结果稳定
+func (s *SetObject) Members() []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.UpdateAccess()
+	return sortedMembers(s.members)
+}
+
+// Len 返回成员数量
+func (s *SetObject) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.UpdateAccess()
+	return len(s.members)
+}
+
+// Size Return object size，O(1)返回增量维护的大小
+func (s *SetObject) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Reset 重置对象以便复用
+func (s *SetObject) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for m := range s.members {
+		delete(s.members, m)
+	}
+	s.size = 2 // []
+	s.BaseObject.reset()
+}
+
+// Clear 清空数据（用于对象池）
+func (s *SetObject) Clear() {
+	s.Reset()
+}
+
+// sortedMembers 返回排序后的成员列表
+func sortedMembers(members map[interface{}]struct{}) []interface{} {
+	result := make([]interface{}, 0, len(members))
+	for m := range members {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := fmt.Sprintf("%T", result[i]), fmt.Sprintf("%T", result[j])
+		if ti != tj {
+			return ti < tj
+		}
+		return fmt.Sprint(result[i]) < fmt.Sprint(result[j])
+	})
+	return result
+}
@@ -0,0 +1,6 @@
+package types
+
+import "github.com/scache-io/scache/interfaces"
+
+// KeyInfo 热点键统计，定义在interfaces中以便StorageEngine接口直接返回
+type KeyInfo = interfaces.KeyInfo
@@ -0,0 +1,39 @@
+package types
+
+import "sort"
+
+// KeyPage 键分页结果
+type KeyPage struct {
+	Keys    []string `json:"keys"`
+	Total   int      `json:"total"`
+	Page    int      `json:"page"`
+	HasNext bool     `json:"has_next"`
+}
+
+// PageKeys 对键排序后返回第page页（从1开始），排序保证相邻页之间不重叠也不遗漏
+func PageKeys(keys []string, page, pageSize int) KeyPage {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 1
+	}
+
+	sort.Strings(keys)
+
+	start := (page - 1) * pageSize
+	if start > len(keys) {
+		start = len(keys)
+	}
+	end := start + pageSize
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	return KeyPage{
+		Keys:    keys[start:end],
+		Total:   len(keys),
+		Page:    page,
+		HasNext: end < len(keys),
+	}
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ValueSize 返回值按JSON序列化后的字节数
+// 常见类型（字符串、数字、布尔、切片、map）直接计算，避免序列化分配；其他类型回退到json.Marshal
+func ValueSize(v interface{}) int {
+	var buf [64]byte
+
+	switch t := v.(type) {
+	case nil:
+		return 4 // null
+	case string:
+		return stringSize(t)
+	case bool:
+		if t {
+			return 4
+		}
+		return 5
+	case int:
+		return len(strconv.AppendInt(buf[:0], int64(t), 10))
+	case int8:
+		return len(strconv.AppendInt(buf[:0], int64(t), 10))
+	case int16:
+		return len(strconv.AppendInt(buf[:0], int64(t), 10))
+	case int32:
+		return len(strconv.AppendInt(buf[:0], int64(t), 10))
+	case int64:
+		return len(strconv.AppendInt(buf[:0], t, 10))
+	case uint:
+		return len(strconv.AppendUint(buf[:0], uint64(t), 10))
+	case uint8:
+		return len(strconv.AppendUint(buf[:0], uint64(t), 10))
+	case uint16:
+		return len(strconv.AppendUint(buf[:0], uint64(t), 10))
+	case uint32:
+		return len(strconv.AppendUint(buf[:0], uint64(t), 10))
+	case uint64:
+		return len(strconv.AppendUint(buf[:0], t, 10))
+	case float32:
+		return len(strconv.AppendFloat(buf[:0], float64(t), 'g', -1, 32))
+	case float64:
+		return len(strconv.AppendFloat(buf[:0], t, 'g', -1, 64))
+	case json.Number:
+		return len(t)
+	case []interface{}:
+		return listSize(t)
+	case map[string]interface{}:
+		return mapSize(t)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// ElementSize 返回value作为JSON数组元素时占用的字节数，others为数组中其他元素的个数，不为0时含一个分隔逗号
+// 对象据此在写入和移除元素时增量维护Size，引擎据此计算单个元素引起的内存变化
+func ElementSize(value interface{}, others int) int {
+	size := ValueSize(value)
+	if others > 0 {
+		size++ // 逗号
+	}
+	return size
+}
+
+// fieldSize JSON对象中一个字段占用的字节数，others为其他字段的个数，不为0时含一个分隔逗号
+func fieldSize(field string, value interface{}, others int) int {
+	size := stringSize(field) + 1 + ValueSize(value) // 键 + 冒号 + 值
+	if others > 0 {
+		size++ // 逗号
+	}
+	return size
+}
+
+// stringSize JSON字符串长度（含引号与转义）
+func stringSize(s string) int {
+	size := 2
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\' || c == '\n' || c == '\r' || c == '\t':
+				size += 2
+			case c < 0x20 || c == '<' || c == '>' || c == '&':
+				size += 6 // \u00XX
+			default:
+				size++
+			}
+			i++
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && n == 1 || r == '\u2028' || r == '\u2029' {
+			size += 6
+		} else {
+			size += n
+		}
+		i += n
+	}
+	return size
+}
+
+// listSize JSON数组长度
+func listSize(values []interface{}) int {
+	size := 2
+	for i, v := range values {
+		if i > 0 {
+			size++ // 逗号
+		}
+		size += ValueSize(v)
+	}
+	return size
+}
+
+// mapSize JSON对象长度
+func mapSize(fields map[string]interface{}) int {
+	size := 2
+	first := true
+	for k, v := range fields {
+		if !first {
+			size++ // 逗号
+		}
+		first = false
+		size += stringSize(k) + 1 + ValueSize(v) // 键 + 冒号 + 值
+	}
+	return size
+}
+
+// setSize 按JSON数组计算Set长度
+func setSize(members map[interface{}]struct{}) int {
+	size := 2
+	first := true
+	for m := range members {
+		if !first {
+			size++ // 逗号
+		}
+		first = false
+		size += ValueSize(m)
+	}
+	return size
+}
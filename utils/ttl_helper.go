@@ -14,11 +14,16 @@ func ParseTTL(ttl []time.Duration) time.Duration {
 // CalculateRemainingTTL 计算剩余生存时间
 // 统一TTL计算逻辑
 func CalculateRemainingTTL(expiresAt time.Time) (time.Duration, bool) {
+	return CalculateRemainingTTLAt(expiresAt, time.Now())
+}
+
+// CalculateRemainingTTLAt 以now作为当前时间计算剩余生存时间
+func CalculateRemainingTTLAt(expiresAt, now time.Time) (time.Duration, bool) {
 	if expiresAt.IsZero() {
 		return -1, true // 永不过期
 	}
 
-	remaining := time.Until(expiresAt)
+	remaining := expiresAt.Sub(now)
 	if remaining <= 0 {
 		return 0, true // 已过期
 	}
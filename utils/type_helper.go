@@ -1,6 +1,11 @@
 package utils
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/scache-io/scache/errors"
 	"github.com/scache-io/scache/interfaces"
 	"github.com/scache-io/scache/types"
 )
@@ -23,7 +28,7 @@ func ExtractListValue(obj interfaces.DataObject) ([]interface{}, bool) {
 		return nil, false
 	}
 
-	if listObj, ok := obj.(*types.ListObject); ok {
+	if listObj, ok := obj.(interfaces.ListObject); ok {
 		return listObj.Values(), true
 	}
 	return nil, false
@@ -41,6 +46,18 @@ func ExtractHashValue(obj interfaces.DataObject) (map[string]interface{}, bool)
 	return nil, false
 }
 
+// ExtractSetValue 从数据对象中提取Set成员
+func ExtractSetValue(obj interfaces.DataObject) ([]interface{}, bool) {
+	if obj.Type() != interfaces.DataTypeSet {
+		return nil, false
+	}
+
+	if setObj, ok := obj.(interfaces.SetObject); ok {
+		return setObj.Members(), true
+	}
+	return nil, false
+}
+
 // ExtractStructValue 从数据对象中Extract structs值（JSON字符串）
 func ExtractStructValue(obj interfaces.DataObject) (string, bool) {
 	// Struct object底层是StringObject，所以检查字符串Type
@@ -54,6 +71,18 @@ func ExtractStructValue(obj interfaces.DataObject) (string, bool) {
 	return "", false
 }
 
+// IncrementInt 将十进制整数字符串加上delta，值不是整数时返回ErrTypeMismatch，溢出时返回ErrInvalidArgument
+func IncrementInt(value string, delta int64) (int64, error) {
+	current, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: value is not an integer", errors.ErrTypeMismatch)
+	}
+	if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+		return 0, fmt.Errorf("%w: increment would overflow", errors.ErrInvalidArgument)
+	}
+	return current + delta, nil
+}
+
 // IsDataTypeCompatible 检查Data type是否兼容
 func IsDataTypeCompatible(obj interfaces.DataObject, expectedType interfaces.DataType) bool {
 	return obj.Type() == expectedType
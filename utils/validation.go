@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // ValidateCacheKey 验证Cache key是否有效
@@ -37,6 +38,30 @@ func ValidateMemoryThreshold(threshold float64) error {
 	return nil
 }
 
+// ValidateRatio 验证比例Parameter是否在[0, 1]范围内
+func ValidateRatio(name string, ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return fmt.Errorf("invalid argument: %s must be between 0 and 1", name)
+	}
+	return nil
+}
+
+// ValidateDuration 验证时长Parameter是否非负
+func ValidateDuration(name string, d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("invalid argument: %s must be non-negative", name)
+	}
+	return nil
+}
+
+// ValidateCount 验证数量Parameter是否非负
+func ValidateCount(name string, n int) error {
+	if n < 0 {
+		return fmt.Errorf("invalid argument: %s must be non-negative", name)
+	}
+	return nil
+}
+
 // ValidateStructName 验证Struct name是否有效
 func ValidateStructName(name string) error {
 	if name == "" {